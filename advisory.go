@@ -0,0 +1,132 @@
+package latest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Severity levels of Advisory. Source may use other value.
+const (
+	SeverityLow      = "low"
+	SeverityModerate = "moderate"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// Advisory is security advisory reported by source. Check flags
+// CheckResponse as vulnerable when target is in Affected range.
+type Advisory struct {
+	// ID is advisory identifier. e.g., `CVE-2015-0001`, `GHSA-xxxx-xxxx-xxxx`
+	ID string `json:"id"`
+
+	// Summary is short description of the vulnerability.
+	Summary string `json:"summary,omitempty"`
+
+	// Severity is severity level. e.g., `high`
+	Severity string `json:"severity,omitempty"`
+
+	// URL is URL where details of the advisory are described.
+	URL string `json:"url,omitempty"`
+
+	// Affected is affected version range as hashicorp/go-version constraint.
+	// e.g., `>= 1.0.0, < 1.2.3`. With VersionScheme other than the default,
+	// versions are compared by the scheme and only `=`, `!=`, `>`, `>=`,
+	// `<` and `<=` operators are supported.
+	Affected string `json:"affected"`
+
+	// Fixed is version which fixes the vulnerability. It's optional.
+	Fixed string `json:"fixed,omitempty"`
+}
+
+// AdvisorySource is the interface that every security advisory source
+// must implement. Unlike Source, it's queried with target version.
+// See Checker.AdvisorySource.
+type AdvisorySource interface {
+	// Validate is called before FetchAdvisories in Check.
+	Validate() error
+
+	// FetchAdvisories returns advisories which affect target version.
+	// Affected of each advisory must be the range which includes target.
+	FetchAdvisories(target string) ([]*Advisory, error)
+}
+
+// Affects returns true when v is in Affected range. It returns error when
+// Affected can not be parsed.
+func (a *Advisory) Affects(v *version.Version) (bool, error) {
+	constraints, err := version.NewConstraint(a.Affected)
+	if err != nil {
+		return false, err
+	}
+
+	return constraints.Check(v), nil
+}
+
+// affects returns true when v parsed by scheme is in Affected range.
+func (a *Advisory) affects(scheme VersionScheme, v SchemeVersion) (bool, error) {
+	if hv, ok := v.(hashicorpVersion); ok {
+		return a.Affects(hv.v)
+	}
+	return checkConstraint(scheme, a.Affected, v)
+}
+
+// affecting returns advisories which affect v parsed by scheme. Advisory
+// which Affected can not be parsed is ignored.
+func affecting(advisories []*Advisory, scheme VersionScheme, v SchemeVersion) []*Advisory {
+	var res []*Advisory
+	for _, a := range advisories {
+		if a == nil {
+			continue
+		}
+
+		if ok, err := a.affects(scheme, v); err == nil && ok {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// constraintOperators are operators checkConstraint supports. Longer
+// one comes first so that `>=` is not taken as `>`.
+var constraintOperators = []string{"==", "!=", ">=", "<=", "=", ">", "<"}
+
+// checkConstraint checks v against comma separated constraint (e.g.,
+// `>= 1.0, < 2.0`) by comparing versions parsed by scheme. Version
+// without operator must be equal.
+func checkConstraint(scheme VersionScheme, constraint string, v SchemeVersion) (bool, error) {
+	ok := true
+	for _, term := range strings.Split(constraint, ",") {
+		term = strings.TrimSpace(term)
+
+		op := "="
+		for _, o := range constraintOperators {
+			if strings.HasPrefix(term, o) {
+				op, term = o, strings.TrimSpace(term[len(o):])
+				break
+			}
+		}
+
+		cv, err := scheme.Parse(term)
+		if err != nil {
+			return false, fmt.Errorf("malformed constraint %q: %s", constraint, err)
+		}
+
+		c := v.Compare(cv)
+		switch op {
+		case "=", "==":
+			ok = ok && c == 0
+		case "!=":
+			ok = ok && c != 0
+		case ">":
+			ok = ok && c > 0
+		case ">=":
+			ok = ok && c >= 0
+		case "<":
+			ok = ok && c < 0
+		case "<=":
+			ok = ok && c <= 0
+		}
+	}
+	return ok, nil
+}
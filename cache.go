@@ -0,0 +1,77 @@
+package latest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache stores CheckResponse so that Checker doesn't access source on
+// every run. See Checker.Cache.
+type Cache interface {
+	// Load returns response stored with key and when it's stored.
+	// ok is false when nothing is stored.
+	Load(key string) (res *CheckResponse, stored time.Time, ok bool)
+
+	// Store stores response with key.
+	Store(key string, res *CheckResponse) error
+}
+
+// FileCache is Cache which stores responses as JSON files in Dir. Use
+// different Dir for each source (e.g., CacheDir of the tool name).
+type FileCache struct {
+	// Dir is directory where responses are stored. It's created when
+	// it doesn't exist.
+	Dir string
+}
+
+type fileCacheEntry struct {
+	Stored   time.Time      `json:"stored"`
+	Response *CheckResponse `json:"response"`
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.Dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+func (f *FileCache) Load(key string) (*CheckResponse, time.Time, bool) {
+	b, err := ioutil.ReadFile(f.path(key))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+
+	var entry fileCacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil || entry.Response == nil {
+		return nil, time.Time{}, false
+	}
+
+	return entry.Response, entry.Stored, true
+}
+
+func (f *FileCache) Store(key string, res *CheckResponse) error {
+	b, err := json.Marshal(&fileCacheEntry{Stored: timeNow(), Response: res})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(f.Dir, 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(f.path(key), b, 0600)
+}
+
+// CacheDir returns default cache directory of the tool name in user
+// cache directory (e.g., `~/.cache/NAME/go-latest`).
+func CacheDir(name string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name, "go-latest"), nil
+}
@@ -0,0 +1,271 @@
+package latest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReleaseNote is release note of a single version.
+type ReleaseNote struct {
+	// Version is version string of the release.
+	Version string
+
+	// Title is title of the release. It's optional.
+	Title string
+
+	// Body is release note itself.
+	Body string
+
+	// URL is URL where the release is described. It's optional.
+	URL string
+
+	// Date is release date. It's zero when it's unknown.
+	Date time.Time
+}
+
+// ChangelogSource is the interface that every release note source
+// must implement. GithubRelease, JSON and KeepAChangelog implement it.
+type ChangelogSource interface {
+	// Validate is called before FetchReleaseNotes in FetchChangelog.
+	Validate() error
+
+	// FetchReleaseNotes returns release notes of all versions it knows.
+	FetchReleaseNotes() ([]*ReleaseNote, error)
+}
+
+// Changelog is release notes of versions between From (exclusive)
+// and To (inclusive).
+type Changelog struct {
+	From string
+	To   string
+
+	// Notes are ordered from newest version.
+	Notes []*ReleaseNote
+}
+
+// FetchChangelog gathers release notes of every version between target
+// and Current in CheckResponse from ChangelogSource. Versions are compared
+// by the default scheme. To use other scheme, use Checker.FetchChangelog.
+func FetchChangelog(res *CheckResponse, s ChangelogSource) (*Changelog, error) {
+	return (&Checker{}).FetchChangelog(res, s)
+}
+
+// FetchChangelog gathers release notes of every version between target
+// and Current in CheckResponse from ChangelogSource. Versions are compared
+// by Scheme of the checker.
+func (c *Checker) FetchChangelog(res *CheckResponse, s ChangelogSource) (*Changelog, error) {
+	scheme := c.scheme()
+
+	fromV, err := scheme.Parse(res.Target)
+	if err != nil {
+		return nil, &ParseError{Input: res.Target, Err: err}
+	}
+
+	toV, err := scheme.Parse(res.Current)
+	if err != nil {
+		return nil, &ParseError{Input: res.Current, Err: err}
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.FetchReleaseNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	type versionedNote struct {
+		v    SchemeVersion
+		note *ReleaseNote
+	}
+
+	var vnotes []versionedNote
+	for _, note := range notes {
+		v, err := scheme.Parse(note.Version)
+		if err != nil {
+			continue
+		}
+
+		if v.Compare(fromV) > 0 && v.Compare(toV) <= 0 {
+			vnotes = append(vnotes, versionedNote{v: v, note: note})
+		}
+	}
+
+	sort.SliceStable(vnotes, func(i, j int) bool {
+		return vnotes[i].v.Compare(vnotes[j].v) > 0
+	})
+
+	changelog := &Changelog{From: res.Target, To: res.Current}
+	for _, vn := range vnotes {
+		changelog.Notes = append(changelog.Notes, vn.note)
+	}
+
+	return changelog, nil
+}
+
+// WriteTo writes combined summary of release notes for terminal output.
+func (c *Changelog) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "Changes from %s to %s:\n", c.From, c.To)
+	if len(c.Notes) == 0 {
+		fmt.Fprintf(&buf, "\n  No release notes found.\n")
+	}
+
+	for _, note := range c.Notes {
+		header := note.Version
+		if note.Title != "" && note.Title != note.Version {
+			header += " " + note.Title
+		}
+		if !note.Date.IsZero() {
+			header += " (" + note.Date.Format("2006-01-02") + ")"
+		}
+		fmt.Fprintf(&buf, "\n%s\n", header)
+
+		if body := strings.TrimSpace(note.Body); body != "" {
+			for _, line := range strings.Split(body, "\n") {
+				line = strings.TrimRight(line, " \r")
+				if line == "" {
+					buf.WriteString("\n")
+					continue
+				}
+				fmt.Fprintf(&buf, "  %s\n", line)
+			}
+		}
+
+		if note.URL != "" {
+			fmt.Fprintf(&buf, "  %s\n", note.URL)
+		}
+	}
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func (c *Changelog) String() string {
+	var buf bytes.Buffer
+	c.WriteTo(&buf)
+	return buf.String()
+}
+
+// KeepAChangelog is ChangelogSource which fetches CHANGELOG.md in
+// Keep a Changelog format (http://keepachangelog.com/) over HTTP.
+// Each version must start with a level 2 header like below,
+//
+//	## [1.2.0] - 2015-04-14
+//	## 1.2.0 (2015-04-14)
+type KeepAChangelog struct {
+	// URL is URL of raw CHANGELOG.md.
+	URL string
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
+}
+
+func (k *KeepAChangelog) Validate() error {
+
+	if len(k.URL) == 0 {
+		return validationErrorf("URL must be set")
+	}
+
+	// Check URL can be parsed
+	if _, err := url.Parse(k.URL); err != nil {
+		return validationErrorf("%s is invalid URL: %s", k.URL, err.Error())
+	}
+
+	return nil
+}
+
+func (k *KeepAChangelog) FetchReleaseNotes() ([]*ReleaseNote, error) {
+
+	resp, err := httpGet(k.URL, "", k.Retry)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &NetworkError{URL: k.URL, Err: err}
+	}
+
+	return parseKeepAChangelog(string(b)), nil
+}
+
+var (
+	changelogDateRegexp    = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+	changelogLinkRefRegexp = regexp.MustCompile(`^\[([^\]]+)\]:\s*(\S+)`)
+)
+
+// parseKeepAChangelog parses Keep a Changelog format. Sections which
+// version can not be parsed (e.g., `Unreleased`) are skipped.
+func parseKeepAChangelog(s string) []*ReleaseNote {
+	var notes []*ReleaseNote
+	links := make(map[string]string)
+
+	var current *ReleaseNote
+	var body []string
+	flush := func() {
+		if current != nil {
+			current.Body = strings.TrimSpace(strings.Join(body, "\n"))
+			notes = append(notes, current)
+		}
+		current, body = nil, nil
+	}
+
+	sc := bufio.NewScanner(strings.NewReader(s))
+	for sc.Scan() {
+		line := sc.Text()
+
+		if m := changelogLinkRefRegexp.FindStringSubmatch(line); m != nil {
+			links[m[1]] = m[2]
+			continue
+		}
+
+		if strings.HasPrefix(line, "## ") {
+			flush()
+
+			header := strings.TrimPrefix(line, "## ")
+			fields := strings.Fields(header)
+			if len(fields) == 0 {
+				continue
+			}
+
+			// Version is parsed later by scheme, so only `Unreleased`
+			// is skipped here
+			verStr := strings.Trim(fields[0], "[]")
+			if strings.EqualFold(verStr, "Unreleased") {
+				continue
+			}
+
+			current = &ReleaseNote{Version: verStr}
+			if d := changelogDateRegexp.FindString(header); d != "" {
+				current.Date, _ = time.Parse("2006-01-02", d)
+			}
+			continue
+		}
+
+		if current != nil {
+			body = append(body, line)
+		}
+	}
+	flush()
+
+	for _, note := range notes {
+		if u, ok := links[note.Version]; ok {
+			note.URL = u
+		}
+	}
+
+	return notes
+}
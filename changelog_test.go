@@ -0,0 +1,176 @@
+package latest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeepAChangelog_implement(t *testing.T) {
+	var _ ChangelogSource = &KeepAChangelog{}
+	var _ ChangelogSource = &GithubRelease{}
+	var _ ChangelogSource = &JSON{}
+}
+
+func TestParseKeepAChangelog(t *testing.T) {
+	notes := parseKeepAChangelog(`# Change Log
+
+## [Unreleased]
+
+- Work in progress
+
+## [1.5.0] - 2015-06-01
+
+### Added
+
+- Parallel upload
+
+## 1.4.0 (2015-05-01)
+
+- Memory leak
+
+[1.5.0]: https://github.com/tcnksm/ghr/compare/v1.4.0...v1.5.0
+`)
+
+	if len(notes) != 2 {
+		t.Fatalf("parseKeepAChangelog() expects number of notes %d to be 2", len(notes))
+	}
+
+	n := notes[0]
+	if n.Version != "1.5.0" || n.Body != "### Added\n\n- Parallel upload" ||
+		n.URL != "https://github.com/tcnksm/ghr/compare/v1.4.0...v1.5.0" ||
+		!n.Date.Equal(time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("parseKeepAChangelog() returns unexpected note %#v", n)
+	}
+
+	n = notes[1]
+	if n.Version != "1.4.0" || n.Body != "- Memory leak" || n.URL != "" ||
+		!n.Date.Equal(time.Date(2015, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("parseKeepAChangelog() returns unexpected note %#v", n)
+	}
+}
+
+func TestFetchChangelog(t *testing.T) {
+	changelog := fakeServer("test-fixtures/CHANGELOG.md")
+	defer changelog.Close()
+
+	releases := fakeServer("test-fixtures/releases.json")
+	defer releases.Close()
+
+	github := fakeGithubServer("/repos/tcnksm/ghr/releases", "test-fixtures/github_releases.json")
+	defer github.Close()
+
+	tests := []struct {
+		source         ChangelogSource
+		target         string
+		current        string
+		expectVersions []string
+	}{
+		{
+			source:         &KeepAChangelog{URL: changelog.URL},
+			target:         "1.2.0",
+			current:        "1.5.0",
+			expectVersions: []string{"1.5.0", "1.4.0"},
+		},
+		{
+			source:         &KeepAChangelog{URL: changelog.URL},
+			target:         "1.4.0",
+			current:        "1.4.0",
+			expectVersions: nil,
+		},
+		{
+			source:         &JSON{URL: releases.URL},
+			target:         "1.0.0",
+			current:        "1.5.0",
+			expectVersions: []string{"1.5.0", "1.4.0", "1.2.0"},
+		},
+		{
+			source:         &GithubRelease{Owner: "tcnksm", Repository: "ghr", URL: github.URL},
+			target:         "v0.1.0",
+			current:        "0.2.0",
+			expectVersions: []string{"v0.2.0", "v0.1.2"},
+		},
+	}
+
+	for i, tt := range tests {
+		res := &CheckResponse{Target: tt.target, Current: tt.current}
+		c, err := FetchChangelog(res, tt.source)
+		if err != nil {
+			t.Fatalf("#%d FetchChangelog() expects error:%q to be nil", i, err.Error())
+		}
+
+		var versions []string
+		for _, n := range c.Notes {
+			versions = append(versions, n.Version)
+		}
+
+		if len(versions) != len(tt.expectVersions) {
+			t.Fatalf("#%d FetchChangelog() expects %v to be %v", i, versions, tt.expectVersions)
+		}
+		for j := range versions {
+			if versions[j] != tt.expectVersions[j] {
+				t.Fatalf("#%d FetchChangelog() expects %v to be %v", i, versions, tt.expectVersions)
+			}
+		}
+	}
+}
+
+func TestChangelogString(t *testing.T) {
+	c := &Changelog{
+		From: "1.2.0",
+		To:   "1.5.0",
+		Notes: []*ReleaseNote{
+			{
+				Version: "1.5.0",
+				Title:   "Parallel upload",
+				Body:    "- Parallel upload\r\n\r\n- Faster",
+				URL:     "http://example.com/1.5.0",
+				Date:    time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				Version: "1.4.0",
+			},
+		},
+	}
+
+	expect := `Changes from 1.2.0 to 1.5.0:
+
+1.5.0 Parallel upload (2015-06-01)
+  - Parallel upload
+
+  - Faster
+  http://example.com/1.5.0
+
+1.4.0
+`
+	if got := c.String(); got != expect {
+		t.Fatalf("String() expects %q to be %q", got, expect)
+	}
+}
+
+func TestCheckerFetchChangelog_scheme(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "# Changelog\n\n## [Unreleased]\n\n## [1.0.post1]\n\nFix packaging\n\n## [1.0]\n\nFirst release\n\n## [1.0rc1]\n\nRelease candidate\n")
+	}))
+	defer ts.Close()
+
+	c := &Checker{Scheme: PEP440{}}
+	res := &CheckResponse{Target: "1.0rc1", Current: "1.0.post1"}
+	changelog, err := c.FetchChangelog(res, &KeepAChangelog{URL: ts.URL})
+	if err != nil {
+		t.Fatalf("FetchChangelog() expects error:%q to be nil", err.Error())
+	}
+
+	var versions []string
+	for _, n := range changelog.Notes {
+		versions = append(versions, n.Version)
+	}
+
+	expect := []string{"1.0.post1", "1.0"}
+	if !reflect.DeepEqual(versions, expect) {
+		t.Fatalf("FetchChangelog() expects %v to be %v", versions, expect)
+	}
+}
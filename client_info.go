@@ -0,0 +1,72 @@
+package latest
+
+import (
+	"net/url"
+	"runtime"
+)
+
+// Query parameter names used to send ClientInfo.
+const (
+	ClientVersionParam = "version"
+	ClientOSParam      = "os"
+	ClientArchParam    = "arch"
+	ClientChannelParam = "channel"
+)
+
+// ClientInfo is information about running client. It is sent to server as
+// query parameters when it's set to source (opt-in), so that server can return
+// response tailored to the client (e.g., "1.2.x has a CVE, upgrade now").
+// See server package for server side implementation.
+type ClientInfo struct {
+	// Version is current version of the client.
+	Version string
+
+	// OS and Arch are platform of the client. e.g., `linux` and `amd64`.
+	OS   string
+	Arch string
+
+	// Channel is release channel the client follows. e.g., `beta`.
+	Channel string
+}
+
+// NewClientInfo returns ClientInfo with given version and
+// platform of running binary (runtime.GOOS and runtime.GOARCH).
+func NewClientInfo(version string) *ClientInfo {
+	return &ClientInfo{
+		Version: version,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	}
+}
+
+// ClientInfoFromQuery extracts ClientInfo from query parameters.
+// It's used by server side.
+func ClientInfoFromQuery(q url.Values) *ClientInfo {
+	return &ClientInfo{
+		Version: q.Get(ClientVersionParam),
+		OS:      q.Get(ClientOSParam),
+		Arch:    q.Get(ClientArchParam),
+		Channel: q.Get(ClientChannelParam),
+	}
+}
+
+// addQuery adds non-empty fields to URL query parameters. Existing
+// parameters on URL are kept, even when they have the same key.
+func (c *ClientInfo) addQuery(u *url.URL) {
+	if c == nil {
+		return
+	}
+
+	q := u.Query()
+	for _, p := range []struct{ key, val string }{
+		{ClientVersionParam, c.Version},
+		{ClientOSParam, c.OS},
+		{ClientArchParam, c.Arch},
+		{ClientChannelParam, c.Channel},
+	} {
+		if p.val != "" && q.Get(p.key) == "" {
+			q.Set(p.key, p.val)
+		}
+	}
+	u.RawQuery = q.Encode()
+}
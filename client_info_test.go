@@ -0,0 +1,106 @@
+package latest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// queryServer returns server which records query parameters of the last
+// request and responds fixture.
+func queryServer(fixture string, query *url.Values) *httptest.Server {
+	h := fakeHandler(fixture)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*query = r.URL.Query()
+		h.ServeHTTP(w, r)
+	}))
+}
+
+func TestClientInfo_query(t *testing.T) {
+	tests := []struct {
+		source func(u string) Source
+		expect url.Values
+	}{
+		{
+			source: func(u string) Source {
+				return &JSON{URL: u}
+			},
+			expect: url.Values{},
+		},
+		{
+			source: func(u string) Source {
+				return &JSON{
+					URL:    u + "?key=val",
+					Client: &ClientInfo{Version: "1.2.0", OS: "linux", Arch: "amd64"},
+				}
+			},
+			expect: url.Values{
+				"key":     {"val"},
+				"version": {"1.2.0"},
+				"os":      {"linux"},
+				"arch":    {"amd64"},
+			},
+		},
+		{
+			// Parameter already on URL is kept
+			source: func(u string) Source {
+				return &JSON{
+					URL:    u + "?os=darwin&channel=beta",
+					Client: &ClientInfo{Version: "1.2.0", OS: "linux", Channel: "stable"},
+				}
+			},
+			expect: url.Values{
+				"version": {"1.2.0"},
+				"os":      {"darwin"},
+				"channel": {"beta"},
+			},
+		},
+		{
+			source: func(u string) Source {
+				return &HTMLMeta{
+					URL:     u,
+					Name:    "reduce-worker",
+					Channel: "beta",
+					Client:  &ClientInfo{Version: "1.2.0"},
+				}
+			},
+			expect: url.Values{
+				"version": {"1.2.0"},
+				"channel": {"beta"},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		var query url.Values
+		fixture := "test-fixtures/default.json"
+		if _, ok := tt.source("").(*HTMLMeta); ok {
+			fixture = "test-fixtures/meta_channel.html"
+		}
+
+		ts := queryServer(fixture, &query)
+		defer ts.Close()
+
+		if _, err := tt.source(ts.URL).Fetch(); err != nil {
+			t.Fatalf("#%d Fetch() expects error:%q to be nil", i, err.Error())
+		}
+
+		if !reflect.DeepEqual(query, tt.expect) {
+			t.Fatalf("#%d Fetch() expects query %v to be %v", i, query, tt.expect)
+		}
+	}
+}
+
+func TestClientInfoFromQuery(t *testing.T) {
+	c := &ClientInfo{Version: "1.2.0", OS: "darwin", Arch: "arm64", Channel: "beta"}
+
+	u, _ := url.Parse("http://example.com/info")
+	c.addQuery(u)
+
+	got := ClientInfoFromQuery(u.Query())
+	if !reflect.DeepEqual(got, c) {
+		t.Fatalf("ClientInfoFromQuery() expects %#v to be %#v", got, c)
+	}
+}
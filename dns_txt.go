@@ -0,0 +1,103 @@
+package latest
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/hashicorp/go-version"
+)
+
+// DNSTXT is used to fetch version information from DNS TXT record.
+// It is cheap and cacheable, and works where HTTPS egress is blocked but DNS
+// isn't. TXT record has the same format as HTML meta tag content,
+//
+//	_go-latest.reduce-worker.example.com. IN TXT "reduce-worker 1.2.3 New version include security update"
+//
+// A record name MAY have multiple TXT records. All of them are used for
+// version comparison, and Meta.Message is taken from the greatest version.
+type DNSTXT struct {
+	// Record is DNS name which has TXT records.
+	// e.g., `_go-latest.reduce-worker.example.com`
+	Record string
+
+	// Name is tool name which you want to check. This name must be
+	// written in TXT record. DNSTXT use this to extract version information.
+	Name string
+
+	// Resolver is used to lookup TXT records. By default,
+	// net.DefaultResolver is used.
+	Resolver TXTResolver
+}
+
+// TXTResolver is used to lookup DNS TXT records. *net.Resolver
+// implements this interface.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+func (d *DNSTXT) resolver() TXTResolver {
+	if d.Resolver == nil {
+		return net.DefaultResolver
+	}
+
+	return d.Resolver
+}
+
+func (d *DNSTXT) Validate() error {
+
+	if len(d.Record) == 0 {
+		return validationErrorf("Record must be set")
+	}
+
+	if len(d.Name) == 0 {
+		return validationErrorf("Name must be set")
+	}
+
+	return nil
+}
+
+func (d *DNSTXT) Fetch() (*FetchResponse, error) {
+
+	fr := newFetchResponse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultDialTimeout)
+	defer cancel()
+
+	txts, err := d.resolver().LookupTXT(ctx, d.Record)
+	if err != nil {
+		// Record which doesn't exist is not a transient failure
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return fr, noVersionsError("TXT record %s is not found", d.Record)
+		}
+		return fr, &NetworkError{URL: "dns:" + d.Record, Err: err}
+	}
+
+	var current *version.Version
+	for _, txt := range txts {
+		product, verStr, message := contentAnalizer(txt)
+		if product != d.Name {
+			continue
+		}
+
+		r := fr.addRelease(verStr, verStr)
+		r.Notes = message
+
+		v, err := version.NewVersion(verStr)
+		if err != nil {
+			continue
+		}
+
+		if current == nil || v.GreaterThan(current) {
+			current = v
+			fr.Meta.Message = message
+		}
+	}
+
+	if len(fr.Versions) == 0 && len(fr.Malformeds) == 0 {
+		return fr, noVersionsError("version info for %s is not found on %s", d.Name, d.Record)
+	}
+
+	return fr, nil
+}
@@ -0,0 +1,186 @@
+package latest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeDNSServer starts DNS stub server on local UDP port which answers
+// TXT records. It returns resolver which queries the server.
+func fakeDNSServer(t *testing.T, records map[string][]string) (*net.Resolver, func()) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var req dnsmessage.Message
+			if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) == 0 {
+				continue
+			}
+
+			q := req.Questions[0]
+			res := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: req.ID, Response: true, Authoritative: true},
+				Questions: req.Questions,
+			}
+
+			txts, ok := records[strings.TrimSuffix(q.Name.String(), ".")]
+			if !ok {
+				res.RCode = dnsmessage.RCodeNameError
+			}
+
+			if q.Type == dnsmessage.TypeTXT {
+				for _, txt := range txts {
+					res.Answers = append(res.Answers, dnsmessage.Resource{
+						Header: dnsmessage.ResourceHeader{Name: q.Name, Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET, TTL: 60},
+						Body:   &dnsmessage.TXTResource{TXT: []string{txt}},
+					})
+				}
+			}
+
+			b, err := res.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(b, addr)
+		}
+	}()
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", conn.LocalAddr().String())
+		},
+	}
+
+	return resolver, func() { conn.Close() }
+}
+
+func TestDNSTXT_implement(t *testing.T) {
+	var _ Source = &DNSTXT{}
+	var _ TXTResolver = &net.Resolver{}
+}
+
+func TestDNSTXTValidate(t *testing.T) {
+
+	tests := []struct {
+		DNSTXT    *DNSTXT
+		expectErr bool
+	}{
+		{
+			DNSTXT: &DNSTXT{
+				Record: "_go-latest.reduce-worker.example.com",
+				Name:   "reduce-worker",
+			},
+			expectErr: false,
+		},
+		{
+			DNSTXT: &DNSTXT{
+				Name: "reduce-worker",
+			},
+			expectErr: true,
+		},
+		{
+			DNSTXT: &DNSTXT{
+				Record: "_go-latest.reduce-worker.example.com",
+			},
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		err := tt.DNSTXT.Validate()
+		if tt.expectErr == (err == nil) {
+			t.Fatalf("#%d Validate() expects err == nil to eq %t", i, tt.expectErr)
+		}
+	}
+}
+
+func TestDNSTXTFetch(t *testing.T) {
+	resolver, stop := fakeDNSServer(t, map[string][]string{
+		"_go-latest.example.com": {
+			"reduce-worker 1.2.0",
+			"reduce-worker 1.2.1 New version include security update",
+			"great-worker 0.1.1",
+		},
+	})
+	defer stop()
+
+	tests := []struct {
+		record        string
+		name          string
+		expectCurrent string
+		expectMessage string
+		expectErr     error
+	}{
+		{
+			record:        "_go-latest.example.com",
+			name:          "reduce-worker",
+			expectCurrent: "1.2.1",
+			expectMessage: "New version include security update",
+		},
+		{
+			record:        "_go-latest.example.com",
+			name:          "great-worker",
+			expectCurrent: "0.1.1",
+		},
+		{
+			record:    "_go-latest.example.com",
+			name:      "unknown-worker",
+			expectErr: ErrNoVersions,
+		},
+		{
+			// NXDOMAIN is not a network error
+			record:    "_go-latest.unknown.example.com",
+			name:      "reduce-worker",
+			expectErr: ErrNoVersions,
+		},
+	}
+
+	for i, tt := range tests {
+		d := &DNSTXT{
+			Record:   tt.record,
+			Name:     tt.name,
+			Resolver: resolver,
+		}
+
+		fr, err := d.Fetch()
+		if tt.expectErr != nil {
+			if !errors.Is(err, tt.expectErr) || errors.Is(err, ErrNetwork) {
+				t.Fatalf("#%d Fetch() expects error %v to be %v", i, err, tt.expectErr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("#%d Fetch() expects error:%q to be nil", i, err.Error())
+		}
+
+		versions := fr.Versions
+		sort.Sort(version.Collection(versions))
+		current := versions[len(versions)-1].String()
+		if current != tt.expectCurrent {
+			t.Fatalf("#%d Fetch() expects %s to be %s", i, current, tt.expectCurrent)
+		}
+
+		if fr.Meta.Message != tt.expectMessage {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.Message, tt.expectMessage)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package latest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sentinel errors to classify errors returned by Check and sources with
+// errors.Is. e.g.,
+//
+//	if errors.Is(err, latest.ErrNetwork) {
+//	    // Ignore network outage
+//	}
+//
+// To get details, use errors.As with ValidationError, NetworkError,
+// StatusError, RateLimitError or ParseError.
+var (
+	// ErrValidation is returned when source (or its option) is misconfigured.
+	ErrValidation = errors.New("validation failed")
+
+	// ErrNetwork is returned when source can not be reached.
+	ErrNetwork = errors.New("network error")
+
+	// ErrStatus is returned when source responds unexpected HTTP status.
+	ErrStatus = errors.New("unexpected status")
+
+	// ErrRateLimited is returned when source rejects request by rate limit.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrParse is returned when response or version can not be parsed.
+	ErrParse = errors.New("parse error")
+
+	// ErrNoVersions is returned when source has no version information.
+	ErrNoVersions = errors.New("no version to compare")
+)
+
+// ValidationError is returned by Validate when source is misconfigured.
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func validationErrorf(format string, a ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, a...)}
+}
+
+// NetworkError is returned when request to URL fails before receiving
+// response (e.g., DNS failure, connection reset or timeout).
+type NetworkError struct {
+	URL string
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("failed to request %s: %s", e.URL, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NetworkError) Is(target error) bool {
+	return target == ErrNetwork
+}
+
+// StatusError is returned when source responds unexpected HTTP status.
+// Body is the first part of response body to help debugging.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unknown status: %d (%s)", e.StatusCode, e.URL)
+	}
+	return fmt.Sprintf("unknown status: %d (%s): %s", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *StatusError) Is(target error) bool {
+	return target == ErrStatus
+}
+
+// RateLimitError is returned when source rejects request by rate limit.
+// Reset is the time when rate limit is reset (zero if unknown).
+type RateLimitError struct {
+	URL   string
+	Reset time.Time
+	Err   error
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("rate limited by %s", e.URL)
+	if !e.Reset.IsZero() {
+		msg += fmt.Sprintf(" until %s", e.Reset.Format(time.RFC3339))
+	}
+	if e.Err != nil {
+		msg += fmt.Sprintf(": %s", e.Err)
+	}
+	return msg
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// ParseError is returned when Input (response from source or version
+// string) can not be parsed.
+type ParseError struct {
+	Input string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse %s, %s", e.Input, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// noVersionsError returns error which wraps ErrNoVersions.
+func noVersionsError(format string, a ...interface{}) error {
+	return fmt.Errorf("%s: %w", fmt.Sprintf(format, a...), ErrNoVersions)
+}
+
+// wrapParseError wraps err as ParseError of input unless it's already
+// classified (e.g., ValidationError or ErrNoVersions).
+func wrapParseError(input string, err error) error {
+	for _, target := range []error{ErrValidation, ErrNetwork, ErrStatus, ErrRateLimited, ErrParse, ErrNoVersions} {
+		if errors.Is(err, target) {
+			return err
+		}
+	}
+
+	return &ParseError{Input: input, Err: err}
+}
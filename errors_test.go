@@ -0,0 +1,139 @@
+package latest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrors_source(t *testing.T) {
+	tests := []struct {
+		handler http.HandlerFunc
+		expect  error
+	}{
+		{
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte("internal error"))
+			},
+			expect: ErrStatus,
+		},
+		{
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			expect: ErrRateLimited,
+		},
+		{
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", "1500000000")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expect: ErrRateLimited,
+		},
+		{
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("{"))
+			},
+			expect: ErrParse,
+		},
+	}
+
+	for i, tt := range tests {
+		ts := httptest.NewServer(tt.handler)
+		j := &JSON{URL: ts.URL}
+		_, err := j.Fetch()
+		ts.Close()
+
+		if !errors.Is(err, tt.expect) {
+			t.Fatalf("#%d expects %q to be %q", i, err, tt.expect)
+		}
+	}
+}
+
+func TestErrors_status(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(strings.Repeat("a", 1024)))
+	}))
+	defer ts.Close()
+
+	h := &HTML{URL: ts.URL}
+	_, err := h.Fetch()
+
+	var se *StatusError
+	if !errors.As(err, &se) {
+		t.Fatalf("expects %q to be StatusError", err)
+	}
+
+	if se.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expects %d to be %d", se.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	if len(se.Body) != maxErrorBodySize {
+		t.Fatalf("expects body size %d to be %d", len(se.Body), maxErrorBodySize)
+	}
+}
+
+func TestErrors_rateLimitReset(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1500000000")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	f := &Feed{URL: ts.URL}
+	_, err := f.Fetch()
+
+	var rle *RateLimitError
+	if !errors.As(err, &rle) {
+		t.Fatalf("expects %q to be RateLimitError", err)
+	}
+
+	if expect := time.Unix(1500000000, 0); !rle.Reset.Equal(expect) {
+		t.Fatalf("expects %s to be %s", rle.Reset, expect)
+	}
+
+	// RateLimitError wraps StatusError
+	if !errors.Is(err, ErrStatus) {
+		t.Fatalf("expects %q to wrap StatusError", err)
+	}
+}
+
+func TestErrors_network(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+
+	h := &HTML{URL: ts.URL}
+	_, err := h.Fetch()
+	if !errors.Is(err, ErrNetwork) {
+		t.Fatalf("expects %q to be %q", err, ErrNetwork)
+	}
+}
+
+func TestErrors_check(t *testing.T) {
+	tests := []struct {
+		target string
+		source Source
+		expect error
+	}{
+		{"1.0.0", &JSON{}, ErrValidation},
+		{"1.0.0", &GithubTag{}, ErrValidation},
+		{"1.0.0", nil, ErrValidation},
+		{"foo", &fakeSource{versions: []string{"1.0.0"}}, ErrParse},
+		{"1.0.0", &fakeSource{}, ErrNoVersions},
+	}
+
+	for i, tt := range tests {
+		_, err := (&Checker{Source: tt.source}).Check(tt.target)
+		if !errors.Is(err, tt.expect) {
+			t.Fatalf("#%d expects %q to be %q", i, err, tt.expect)
+		}
+	}
+}
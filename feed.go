@@ -0,0 +1,213 @@
+package latest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Feed is used to fetch version information from RSS 2.0 or Atom feed.
+// e.g., GitHub publishes release feed on https://github.com/OWNER/REPO/releases.atom
+type Feed struct {
+	// URL is RSS or Atom feed URL.
+	URL string
+
+	// FixVersionStrFunc is function to fix version string (in this case
+	// entry title or link) so that it can be interpreted as Semantic Versioning
+	// by hashicorp/go-version. By default, it does nothing.
+	FixVersionStrFunc FixVersionStrFunc
+
+	// VersionFromLink is used to extract version from last path element of
+	// entry link instead of entry title. e.g., `v1.2.3` is extracted from
+	// https://github.com/tcnksm/ghr/releases/tag/v1.2.3
+	VersionFromLink bool
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
+}
+
+// feedDocument is used to decode both RSS 2.0 and Atom.
+type feedDocument struct {
+	XMLName xml.Name
+	Items   []rssItem   `xml:"channel>item"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate"`
+}
+
+type atomEntry struct {
+	Title   string     `xml:"title"`
+	Links   []atomLink `xml:"link"`
+	Summary string     `xml:"summary"`
+	Content string     `xml:"content"`
+	Updated string     `xml:"updated"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// feedEntry is common expression of RSS item and Atom entry.
+type feedEntry struct {
+	title   string
+	link    string
+	summary string
+	date    time.Time
+}
+
+func (e *atomEntry) link() string {
+	for _, l := range e.Links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+func (d *feedDocument) entries() ([]*feedEntry, error) {
+	var entries []*feedEntry
+
+	switch d.XMLName.Local {
+	case "rss":
+		for _, item := range d.Items {
+			entries = append(entries, &feedEntry{
+				title:   item.Title,
+				link:    item.Link,
+				summary: item.Description,
+				date:    parseFeedDate(item.PubDate),
+			})
+		}
+
+	case "feed":
+		for _, entry := range d.Entries {
+			summary := entry.Summary
+			if summary == "" {
+				summary = entry.Content
+			}
+			entries = append(entries, &feedEntry{
+				title:   entry.Title,
+				link:    entry.link(),
+				summary: summary,
+				date:    parseFeedDate(entry.Updated),
+			})
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown feed format: <%s>", d.XMLName.Local)
+	}
+
+	return entries, nil
+}
+
+// parseFeedDate parses RSS (RFC 822) or Atom (RFC 3339) date.
+// It returns zero time when it can not be parsed.
+func parseFeedDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339, time.RFC1123Z, time.RFC1123, time.RFC822Z, time.RFC822} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (f *Feed) fixVersionStrFunc() FixVersionStrFunc {
+	if f.FixVersionStrFunc == nil {
+		return defaultFixVersionStrFunc
+	}
+
+	return f.FixVersionStrFunc
+}
+
+func (f *Feed) versionStr(e *feedEntry) string {
+	if f.VersionFromLink {
+		u, err := url.Parse(e.link)
+		if err != nil {
+			return e.link
+		}
+		return path.Base(u.Path)
+	}
+
+	return strings.TrimSpace(e.title)
+}
+
+func (f *Feed) Validate() error {
+
+	if len(f.URL) == 0 {
+		return validationErrorf("URL must be set")
+	}
+
+	// Check URL can be parsed
+	if _, err := url.Parse(f.URL); err != nil {
+		return validationErrorf("%s is invalid URL: %s", f.URL, err.Error())
+	}
+
+	return nil
+}
+
+func (f *Feed) Fetch() (*FetchResponse, error) {
+
+	fr := newFetchResponse()
+
+	resp, err := httpGet(f.URL, "application/atom+xml, application/rss+xml, application/xml", f.Retry)
+	if err != nil {
+		return fr, err
+	}
+	defer resp.Body.Close()
+
+	var doc feedDocument
+	if err := xml.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return fr, &ParseError{Input: f.URL, Err: err}
+	}
+
+	entries, err := doc.entries()
+	if err != nil {
+		return fr, &ParseError{Input: f.URL, Err: err}
+	}
+
+	if len(entries) == 0 {
+		return fr, noVersionsError("version info is not found on %s", f.URL)
+	}
+
+	// fixF is FixVersionStrFunc transform title or link into SemVer string
+	// By default, it does nothing.
+	fixF := f.fixVersionStrFunc()
+
+	var newest *feedEntry
+	for _, e := range entries {
+		name := f.versionStr(e)
+		r := fr.addRelease(name, fixF(name))
+		r.Date = e.date
+		r.URL = e.link
+		r.Notes = strings.TrimSpace(e.summary)
+
+		if _, err := version.NewVersion(r.Version); err != nil {
+			continue
+		}
+
+		// Feed is usually ordered from newest one. Use date
+		// only when it's available.
+		if newest == nil || e.date.After(newest.date) {
+			newest = e
+		}
+	}
+
+	if newest != nil {
+		fr.Meta.Message = strings.TrimSpace(newest.summary)
+		fr.Meta.URL = newest.link
+	}
+
+	return fr, nil
+}
@@ -0,0 +1,125 @@
+package latest
+
+import (
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestFeed_implement(t *testing.T) {
+	var _ Source = &Feed{}
+}
+
+func TestFeedValidate(t *testing.T) {
+
+	tests := []struct {
+		Feed      *Feed
+		expectErr bool
+	}{
+		{
+			Feed: &Feed{
+				URL: "http://good.com/releases.atom",
+			},
+			expectErr: false,
+		},
+		{
+			Feed: &Feed{
+				URL: "",
+			},
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		err := tt.Feed.Validate()
+		if tt.expectErr == (err == nil) {
+			t.Fatalf("#%d Validate() expects err == nil to eq %t", i, tt.expectErr)
+		}
+	}
+}
+
+func TestFeedFetch(t *testing.T) {
+	tests := []struct {
+		testServer      *httptest.Server
+		fix             FixVersionStrFunc
+		versionFromLink bool
+		expectCurrent   string
+		expectMalformed int
+		expectMessage   string
+		expectURL       string
+	}{
+		{
+			testServer:      fakeServer("test-fixtures/feed.atom"),
+			fix:             DeleteFrontV(),
+			expectCurrent:   "0.2.0",
+			expectMalformed: 1,
+			expectMessage:   "Add parallel upload",
+			expectURL:       "https://github.com/tcnksm/ghr/releases/tag/v0.2.0",
+		},
+		{
+			testServer:      fakeServer("test-fixtures/feed.atom"),
+			versionFromLink: true,
+			expectCurrent:   "0.2.0",
+			expectMalformed: 1,
+			expectMessage:   "Add parallel upload",
+			expectURL:       "https://github.com/tcnksm/ghr/releases/tag/v0.2.0",
+		},
+		{
+			testServer:    fakeServer("test-fixtures/feed.rss"),
+			expectCurrent: "1.2.1",
+			expectMessage: "New version include security update",
+			expectURL:     "http://example.com/releases/1.2.1",
+		},
+	}
+
+	for i, tt := range tests {
+		ts := tt.testServer
+		defer ts.Close()
+
+		f := &Feed{
+			URL:               ts.URL,
+			FixVersionStrFunc: tt.fix,
+			VersionFromLink:   tt.versionFromLink,
+		}
+
+		fr, err := f.Fetch()
+		if err != nil {
+			t.Fatalf("#%d Fetch() expects error:%q to be nil", i, err.Error())
+		}
+
+		versions := fr.Versions
+		if len(versions) == 0 {
+			t.Fatalf("#%d Fetch() expects number of versions found from feed not to be 0", i)
+		}
+
+		sort.Sort(version.Collection(versions))
+		current := versions[len(versions)-1].String()
+		if current != tt.expectCurrent {
+			t.Fatalf("#%d Fetch() expects %s to be %s", i, current, tt.expectCurrent)
+		}
+
+		if len(fr.Malformeds) != tt.expectMalformed {
+			t.Fatalf("#%d Fetch() expects number of malformeds %d to be %d", i, len(fr.Malformeds), tt.expectMalformed)
+		}
+
+		if fr.Meta.Message != tt.expectMessage {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.Message, tt.expectMessage)
+		}
+
+		if fr.Meta.URL != tt.expectURL {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.URL, tt.expectURL)
+		}
+	}
+}
+
+func TestFeedFetch_unknownFormat(t *testing.T) {
+	ts := fakeServer("test-fixtures/meta.html")
+	defer ts.Close()
+
+	f := &Feed{URL: ts.URL}
+	if _, err := f.Fetch(); err == nil {
+		t.Fatalf("Fetch() expects error")
+	}
+}
@@ -0,0 +1,95 @@
+package latest
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// MatchGlob returns TagFilterFunc which accepts tags matched with glob
+// pattern (e.g., `v1.*`). Pattern syntax is the same as path.Match,
+// so `*` doesn't match `/`. Invalid pattern accepts nothing.
+func MatchGlob(pattern string) TagFilterFunc {
+	return func(s string) bool {
+		ok, err := path.Match(pattern, s)
+		return err == nil && ok
+	}
+}
+
+// MatchRegexp returns TagFilterFunc which accepts tags matched with
+// regular expression. It panics if pattern can not be compiled.
+func MatchRegexp(pattern string) TagFilterFunc {
+	re := regexp.MustCompile(pattern)
+	return func(s string) bool {
+		return re.MatchString(s)
+	}
+}
+
+// HasPrefix returns TagFilterFunc which accepts tags which start
+// with prefix.
+func HasPrefix(prefix string) TagFilterFunc {
+	return func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	}
+}
+
+// OnlyStable returns TagFilterFunc which accepts tags which can be parsed
+// by hashicorp/go-version and are not pre-release (e.g., `v1.0.0` is
+// accepted, but `v1.0.0-rc1` is not).
+func OnlyStable() TagFilterFunc {
+	return func(s string) bool {
+		v, err := version.NewVersion(s)
+		return err == nil && v.Prerelease() == ""
+	}
+}
+
+// MatchConstraint returns TagFilterFunc which accepts tags which satisfy
+// version constraint of hashicorp/go-version (e.g., `>= 1.0, < 2.0`).
+// Tags which can not be parsed are not accepted. It panics if constraint
+// can not be parsed.
+func MatchConstraint(constraint string) TagFilterFunc {
+	c, err := version.NewConstraint(constraint)
+	if err != nil {
+		panic(err)
+	}
+
+	return func(s string) bool {
+		v, err := version.NewVersion(s)
+		return err == nil && c.Check(v)
+	}
+}
+
+// And returns TagFilterFunc which accepts tags accepted by all fs.
+// With no function, it accepts everything.
+func And(fs ...TagFilterFunc) TagFilterFunc {
+	return func(s string) bool {
+		for _, f := range fs {
+			if !f(s) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns TagFilterFunc which accepts tags accepted by one of fs.
+// With no function, it accepts nothing.
+func Or(fs ...TagFilterFunc) TagFilterFunc {
+	return func(s string) bool {
+		for _, f := range fs {
+			if f(s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns TagFilterFunc which accepts tags not accepted by f.
+func Not(f TagFilterFunc) TagFilterFunc {
+	return func(s string) bool {
+		return !f(s)
+	}
+}
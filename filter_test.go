@@ -0,0 +1,76 @@
+package latest
+
+import (
+	"testing"
+)
+
+func TestTagFilterFunc(t *testing.T) {
+	tests := []struct {
+		f      TagFilterFunc
+		in     string
+		expect bool
+	}{
+		{MatchGlob("v1.*"), "v1.2.0", true},
+		{MatchGlob("v1.*"), "v2.0.0", false},
+		{MatchGlob("tool/*"), "tool/v1.0.0", true},
+		{MatchGlob("*"), "tool/v1.0.0", false},
+		{MatchGlob("["), "[", false},
+
+		{MatchRegexp(`^v\d+\.\d+\.\d+$`), "v1.2.0", true},
+		{MatchRegexp(`^v\d+\.\d+\.\d+$`), "v1.2.0-rc1", false},
+
+		{HasPrefix("tool/"), "tool/v1.0.0", true},
+		{HasPrefix("tool/"), "other/v1.0.0", false},
+
+		{OnlyStable(), "v1.0.0", true},
+		{OnlyStable(), "v1.0.0-rc1", false},
+		{OnlyStable(), "nightly", false},
+
+		{MatchConstraint(">= 1.0, < 2.0"), "v1.5.0", true},
+		{MatchConstraint(">= 1.0, < 2.0"), "2.0.0", false},
+		{MatchConstraint(">= 1.0, < 2.0"), "nightly", false},
+
+		{And(HasPrefix("v"), OnlyStable()), "v1.0.0", true},
+		{And(HasPrefix("v"), OnlyStable()), "1.0.0", false},
+		{And(), "anything", true},
+
+		{Or(MatchGlob("v1.*"), MatchGlob("v2.*")), "v2.0.0", true},
+		{Or(MatchGlob("v1.*"), MatchGlob("v2.*")), "v3.0.0", false},
+		{Or(), "anything", false},
+
+		{Not(OnlyStable()), "v1.0.0-rc1", true},
+		{And(MatchGlob("v*"), Not(MatchRegexp(`-(alpha|beta)`))), "v1.0.0-beta", false},
+	}
+
+	for i, tt := range tests {
+		if got := tt.f(tt.in); got != tt.expect {
+			t.Fatalf("#%d expects %q to be %t", i, tt.in, tt.expect)
+		}
+	}
+}
+
+func TestGithubTagFetch_filter(t *testing.T) {
+	ts := fakeGithubServer("/repos/tcnksm/ghr/tags", "test-fixtures/github_tags.json")
+	defer ts.Close()
+
+	g := &GithubTag{
+		Owner:             "tcnksm",
+		Repository:        "ghr",
+		URL:               ts.URL,
+		FixVersionStrFunc: DeleteFrontV(),
+		TagFilterFunc:     And(OnlyStable(), Not(MatchGlob("v0.1.*"))),
+	}
+
+	fr, err := g.Fetch()
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	if len(fr.Versions) != 1 || fr.Versions[0].String() != "0.2.0" {
+		t.Fatalf("expects %v to be [0.2.0]", fr.Versions)
+	}
+
+	if len(fr.Filtered) != 3 {
+		t.Fatalf("expects %v to have 3 tags", fr.Filtered)
+	}
+}
@@ -0,0 +1,89 @@
+package latest
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DeleteFrontV delete first `v` charactor on version string.
+// For example version name `v0.1.1` becomes `0.1.1`. `v` which is not
+// at the beginning is kept (e.g., `dev-1.0` is not changed).
+func DeleteFrontV() FixVersionStrFunc {
+	return DeletePrefix("v")
+}
+
+// DeletePrefix deletes prefix from version string if it has.
+// For example, with prefix `release-`, `release-1.2.3` becomes `1.2.3`.
+func DeletePrefix(prefix string) FixVersionStrFunc {
+	return func(s string) string {
+		return strings.TrimPrefix(s, prefix)
+	}
+}
+
+// DeleteSuffix deletes suffix from version string if it has.
+// For example, with suffix `-final`, `1.2.3-final` becomes `1.2.3`.
+func DeleteSuffix(suffix string) FixVersionStrFunc {
+	return func(s string) string {
+		return strings.TrimSuffix(s, suffix)
+	}
+}
+
+// DeleteProjectPrefix deletes project name prefix which is joined by `-`,
+// `_`, `/` or `@` and following `v` charactor. It's useful for tags which
+// include project name (e.g., monorepo tags). For example, with name
+// `mytool`, `mytool-1.2.3`, `mytool/v1.2.3` and `mytool@1.2.3` become
+// `1.2.3`. Version string which doesn't have the prefix is not changed.
+func DeleteProjectPrefix(name string) FixVersionStrFunc {
+	return func(s string) string {
+		if !strings.HasPrefix(s, name) || len(s) <= len(name) {
+			return s
+		}
+
+		rest := s[len(name):]
+		if !strings.ContainsAny(rest[:1], "-_/@") {
+			return s
+		}
+
+		return strings.TrimPrefix(rest[1:], "v")
+	}
+}
+
+// CaptureRegexp extracts version from version string by regular expression.
+// Capture group named `version`, first capture group or whole match is
+// used in this order. If pattern doesn't match, version string is not
+// changed. It panics if pattern can not be compiled.
+//
+// For example, with pattern `(\d+\.\d+\.\d+)`, `tool 1.2.3 (stable)`
+// becomes `1.2.3`.
+func CaptureRegexp(pattern string) FixVersionStrFunc {
+	re := regexp.MustCompile(pattern)
+	return func(s string) string {
+		m := re.FindStringSubmatch(s)
+		if m == nil {
+			return s
+		}
+		return regexpCapture(re, m)
+	}
+}
+
+// UnderscoreToDot replaces `_` with `.`. For example, `1_2_3` becomes
+// `1.2.3`.
+func UnderscoreToDot() FixVersionStrFunc {
+	return func(s string) string {
+		return strings.Replace(s, "_", ".", -1)
+	}
+}
+
+// Chain composes FixVersionStrFuncs. They are applied in the given order.
+// For example, Chain(DeletePrefix("release-"), UnderscoreToDot()) transforms
+// `release-1_2_3` into `1.2.3`. nil function is skipped.
+func Chain(fs ...FixVersionStrFunc) FixVersionStrFunc {
+	return func(s string) string {
+		for _, f := range fs {
+			if f != nil {
+				s = f(s)
+			}
+		}
+		return s
+	}
+}
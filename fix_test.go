@@ -0,0 +1,49 @@
+package latest
+
+import (
+	"testing"
+)
+
+func TestFixVersionStrFunc(t *testing.T) {
+	tests := []struct {
+		f      FixVersionStrFunc
+		in     string
+		expect string
+	}{
+		{DeleteFrontV(), "v0.1.1", "0.1.1"},
+		{DeleteFrontV(), "dev-1.0", "dev-1.0"},
+		{DeleteFrontV(), "0.1.1", "0.1.1"},
+
+		{DeletePrefix("release-"), "release-1.2.3", "1.2.3"},
+		{DeletePrefix("release-"), "1.2.3-release-", "1.2.3-release-"},
+
+		{DeleteSuffix("-final"), "1.2.3-final", "1.2.3"},
+		{DeleteSuffix("-final"), "1.2.3", "1.2.3"},
+
+		{DeleteProjectPrefix("mytool"), "mytool-1.2.3", "1.2.3"},
+		{DeleteProjectPrefix("mytool"), "mytool/v1.2.3", "1.2.3"},
+		{DeleteProjectPrefix("mytool"), "mytool@1.2.3", "1.2.3"},
+		{DeleteProjectPrefix("mytool"), "mytool_v1.2.3", "1.2.3"},
+		{DeleteProjectPrefix("mytool"), "mytoolkit-1.2.3", "mytoolkit-1.2.3"},
+		{DeleteProjectPrefix("mytool"), "other/v1.2.3", "other/v1.2.3"},
+		{DeleteProjectPrefix("mytool"), "mytool", "mytool"},
+
+		{CaptureRegexp(`(\d+\.\d+\.\d+)`), "tool 1.2.3 (stable)", "1.2.3"},
+		{CaptureRegexp(`v(?P<version>[\d.]+)-(\d+)`), "v1.2-45", "1.2"},
+		{CaptureRegexp(`\d+\.\d+`), "go1.21", "1.21"},
+		{CaptureRegexp(`(\d+\.\d+\.\d+)`), "latest", "latest"},
+
+		{UnderscoreToDot(), "1_2_3", "1.2.3"},
+
+		{Chain(DeletePrefix("release-"), UnderscoreToDot()), "release-1_2_3", "1.2.3"},
+		{Chain(DeleteProjectPrefix("mytool"), DeleteSuffix("-final")), "mytool/v1.2.3-final", "1.2.3"},
+		{Chain(nil, DeleteFrontV()), "v1.0", "1.0"},
+		{Chain(), "v1.0", "v1.0"},
+	}
+
+	for i, tt := range tests {
+		if got := tt.f(tt.in); got != tt.expect {
+			t.Fatalf("#%d expects %q to be %q", i, got, tt.expect)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package latest
 
 import (
 	"context"
-	"fmt"
+	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/github"
 	"github.com/hashicorp/go-version"
@@ -48,9 +51,69 @@ type GithubTag struct {
 	// such tags. By default, it does nothing.
 	TagFilterFunc TagFilterFunc
 
+	// Path is path of a module (component) in monorepo, e.g., `cli` or
+	// `tools/gen/v2`. If it's set, only tags under the path (e.g.,
+	// `tools/gen/v2.0.1`) are used, and the path prefix is removed before
+	// TagFilterFunc and FixVersionStrFunc are applied.
+	//
+	// It follows Go modules convention: path with major version suffix
+	// `/vN` (N >= 2) uses only vN.x.x tags, and path without it uses
+	// only v0.x.x and v1.x.x (and +incompatible) tags. Use `.` (or `v2`)
+	// to select tags of the root module.
+	Path string
+
+	// CommitDate uses committer date of the commit which each tag points
+	// to as Release.Date. It's needed to use Checker.MinAge with tags.
+	// Date is fetched lazily by Release.PublishDate, which costs one more
+	// API request per tag. Checker fetches it only for the newest tags
+	// until it finds a mature one. By default, it's false.
+	CommitDate bool
+
 	// URL & Token is used for GitHub Enterprise
 	URL   string
 	Token string
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
+}
+
+// majorSuffixRegexp matches major version suffix of Go module path.
+var majorSuffixRegexp = regexp.MustCompile(`^v([2-9]|[1-9][0-9]+)$`)
+
+// splitModulePath splits module path into tag prefix and major version.
+// major is 0 when path doesn't have major version suffix.
+// e.g., `tools/gen/v2` becomes `tools/gen/` and 2.
+func splitModulePath(p string) (prefix string, major int) {
+	p = strings.Trim(p, "/")
+	if p == "." {
+		return "", 0
+	}
+
+	dir, last := "", p
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		dir, last = p[:i], p[i+1:]
+	}
+
+	if m := majorSuffixRegexp.FindStringSubmatch(last); m != nil {
+		major, _ = strconv.Atoi(m[1])
+		p = dir
+	}
+
+	if p == "" {
+		return "", major
+	}
+	return p + "/", major
+}
+
+// matchMajor checks major version of v is allowed by major version
+// suffix of module path.
+func matchMajor(v *version.Version, major int) bool {
+	vMajor := v.Segments()[0]
+	if major == 0 {
+		return vMajor <= 1 || v.Metadata() == "incompatible"
+	}
+	return vMajor == major
 }
 
 func (g *GithubTag) fixVersionStrFunc() FixVersionStrFunc {
@@ -82,35 +145,97 @@ func filterNothing() TagFilterFunc {
 	}
 }
 
-// DeleteFrontV delete first `v` charactor on version string.
-// For example version name `v0.1.1` becomes `0.1.1`
-func DeleteFrontV() FixVersionStrFunc {
-	return func(s string) string {
-		return strings.Replace(s, "v", "", 1)
-	}
+func (g *GithubTag) newClient() *github.Client {
+	return newGithubClient(g.URL, g.Token)
 }
 
-func (g *GithubTag) newClient() *github.Client {
-	client := github.NewClient(nil)
-	if g.URL != "" {
-		client.BaseURL, _ = url.Parse(g.URL)
+// newGithubClient creates GitHub API client. If baseURL is set, it's
+// used instead of api.github.com (GitHub Enterprise). If token is set,
+// it's sent with every request.
+func newGithubClient(baseURL, token string) *github.Client {
+	var httpClient *http.Client
+	if token != "" {
+		httpClient = &http.Client{
+			Transport: &tokenTransport{token: token},
+		}
+	}
+
+	client := github.NewClient(httpClient)
+	if baseURL != "" {
+		// BaseURL must have a trailing slash
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		client.BaseURL, _ = url.Parse(baseURL)
 	}
 	return client
 }
 
+// tokenTransport is http.RoundTripper which sets GitHub API token.
+type tokenTransport struct {
+	token string
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "token "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// githubError converts error returned by GitHub API client into
+// RateLimitError, StatusError or NetworkError.
+func githubError(err error) error {
+	switch e := err.(type) {
+	case *github.RateLimitError:
+		return &RateLimitError{
+			URL:   e.Response.Request.URL.String(),
+			Reset: e.Rate.Reset.Time,
+			Err:   err,
+		}
+	case *github.AbuseRateLimitError:
+		rle := &RateLimitError{
+			URL: e.Response.Request.URL.String(),
+			Err: err,
+		}
+		if e.RetryAfter != nil {
+			rle.Reset = timeNow().Add(*e.RetryAfter)
+		}
+		return rle
+	case *github.ErrorResponse:
+		statusErr := &StatusError{
+			URL:        e.Response.Request.URL.String(),
+			StatusCode: e.Response.StatusCode,
+			Body:       e.Message,
+		}
+		// Secondary rate limit may not be detected by client
+		if isRateLimited(e.Response) {
+			return &RateLimitError{
+				URL:   statusErr.URL,
+				Reset: rateLimitReset(e.Response.Header),
+				Err:   statusErr,
+			}
+		}
+		return statusErr
+	case *url.Error:
+		return &NetworkError{URL: e.URL, Err: e.Err}
+	}
+
+	return &NetworkError{Err: err}
+}
+
 func (g *GithubTag) Validate() error {
 
 	if len(g.Repository) == 0 {
-		return fmt.Errorf("GitHub repository name must be set")
+		return validationErrorf("GitHub repository name must be set")
 	}
 
 	if len(g.Owner) == 0 {
-		return fmt.Errorf("GitHub owner name must be set")
+		return validationErrorf("GitHub owner name must be set")
 	}
 
 	if g.URL != "" {
 		if _, err := url.Parse(g.URL); err != nil {
-			return fmt.Errorf("GitHub API Url invalid: %s", err)
+			return validationErrorf("GitHub API Url invalid: %s", err)
 		}
 	}
 
@@ -121,17 +246,11 @@ func (g *GithubTag) Fetch() (*FetchResponse, error) {
 
 	fr := newFetchResponse()
 
-	// Create a client
-	client := g.newClient()
-	tags, resp, err := client.Repositories.ListTags(context.Background(), g.Owner, g.Repository, nil)
+	tags, err := g.listTags()
 	if err != nil {
 		return fr, err
 	}
 
-	if resp.StatusCode != 200 {
-		return fr, fmt.Errorf("Unknown status: %d", resp.StatusCode)
-	}
-
 	// fixF is FixVersionStrFunc transform tag name string into SemVer string
 	// By default, it does nothing.
 	fixF := g.fixVersionStrFunc()
@@ -140,18 +259,97 @@ func (g *GithubTag) Fetch() (*FetchResponse, error) {
 	// By default, it filter nothing.
 	filterF := g.tagFilterFunc()
 
+	prefix, major := splitModulePath(g.Path)
+
 	for _, tag := range tags {
-		if !filterF(*tag.Name) {
+		name := *tag.Name
+		if g.Path != "" {
+			// Exclude tags of other modules
+			if !strings.HasPrefix(name, prefix) || strings.Contains(name[len(prefix):], "/") {
+				fr.Malformeds = append(fr.Malformeds, name)
+				fr.Filtered = append(fr.Filtered, name)
+				continue
+			}
+			name = name[len(prefix):]
+		}
+
+		if !filterF(name) {
 			fr.Malformeds = append(fr.Malformeds, *tag.Name)
+			fr.Filtered = append(fr.Filtered, *tag.Name)
 			continue
 		}
-		v, err := version.NewVersion(fixF(*tag.Name))
-		if err != nil {
-			fr.Malformeds = append(fr.Malformeds, fixF(*tag.Name))
+		verStr := fixF(name)
+		if v, err := version.NewVersion(verStr); err == nil && g.Path != "" && !matchMajor(v, major) {
+			fr.Malformeds = append(fr.Malformeds, *tag.Name)
+			fr.Filtered = append(fr.Filtered, *tag.Name)
 			continue
 		}
-		fr.Versions = append(fr.Versions, v)
+
+		r := fr.addRelease(*tag.Name, verStr)
+		r.Commit = tag.GetCommit().GetSHA()
+
+		if g.CommitDate && r.Commit != "" {
+			sha := r.Commit
+			r.date = func() (time.Time, error) {
+				return g.commitDate(sha)
+			}
+		}
 	}
 
 	return fr, nil
 }
+
+// commitDate fetches committer date of the commit.
+func (g *GithubTag) commitDate(sha string) (time.Time, error) {
+	client := g.newClient()
+
+	var commit *github.Commit
+	err := retryPolicyOf(g.Retry).do(func() error {
+		var err error
+		commit, _, err = client.Git.GetCommit(context.Background(), g.Owner, g.Repository, sha)
+		if err != nil {
+			return githubError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return commit.GetCommitter().GetDate(), nil
+}
+
+// listTags lists all tags on GitHub following pagination.
+func (g *GithubTag) listTags() ([]*github.RepositoryTag, error) {
+
+	// Create a client
+	client := g.newClient()
+	opt := &github.ListOptions{PerPage: 100}
+
+	var tags []*github.RepositoryTag
+	for {
+		var page []*github.RepositoryTag
+		var resp *github.Response
+		err := retryPolicyOf(g.Retry).do(func() error {
+			var err error
+			page, resp, err = client.Repositories.ListTags(context.Background(), g.Owner, g.Repository, opt)
+			if err != nil {
+				return githubError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != 200 {
+			return nil, &StatusError{URL: resp.Request.URL.String(), StatusCode: resp.StatusCode}
+		}
+
+		tags = append(tags, page...)
+		if resp.NextPage == 0 {
+			return tags, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,241 @@
+package latest
+
+import (
+	"bufio"
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"github.com/hashicorp/go-version"
+)
+
+// Trailer keys which GithubRelease reads from release body to fill Meta.
+// Each of them is written in a single line, e.g.,
+//
+//	Minimum-Version: 1.0.0
+//	End-Of-Life: 2016-01-01
+const (
+	MinVersionTrailer = "Minimum-Version"
+	EOLTrailer        = "End-Of-Life"
+)
+
+// GithubRelease is used to fetch version(release) information from Github.
+// Unlike GithubTag, drafts are excluded and Meta is filled from the latest
+// release (name, URL and trailers in body).
+type GithubRelease struct {
+	// Owner and Repository are GitHub owner name and its repository name.
+	// e.g., If you want to check https://github.com/tcnksm/ghr version
+	// Repository is `ghr`, and Owner is `tcnksm`.
+	Owner      string
+	Repository string
+
+	// FixVersionStrFunc is function to fix version string (in this case tag
+	// name string) on GitHub so that it can be interpreted as Semantic Versioning
+	// by hashicorp/go-version. By default, it does nothing.
+	FixVersionStrFunc FixVersionStrFunc
+
+	// TagFilterFunc is function to filter releases by tag name. By default,
+	// it does nothing.
+	TagFilterFunc TagFilterFunc
+
+	// Prerelease is used to include releases marked as pre-release.
+	// By default, they are excluded.
+	Prerelease bool
+
+	// URL & Token is used for GitHub Enterprise
+	URL   string
+	Token string
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
+}
+
+func (g *GithubRelease) fixVersionStrFunc() FixVersionStrFunc {
+	if g.FixVersionStrFunc == nil {
+		return defaultFixVersionStrFunc
+	}
+
+	return g.FixVersionStrFunc
+}
+
+func (g *GithubRelease) tagFilterFunc() TagFilterFunc {
+	if g.TagFilterFunc == nil {
+		return defaultTagFilterFunc
+	}
+
+	return g.TagFilterFunc
+}
+
+func (g *GithubRelease) Validate() error {
+
+	if len(g.Repository) == 0 {
+		return validationErrorf("GitHub repository name must be set")
+	}
+
+	if len(g.Owner) == 0 {
+		return validationErrorf("GitHub owner name must be set")
+	}
+
+	if g.URL != "" {
+		if _, err := url.Parse(g.URL); err != nil {
+			return validationErrorf("GitHub API Url invalid: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (g *GithubRelease) Fetch() (*FetchResponse, error) {
+
+	fr := newFetchResponse()
+
+	releases, err := g.listReleases()
+	if err != nil {
+		return fr, err
+	}
+
+	fixF := g.fixVersionStrFunc()
+	filterF := g.tagFilterFunc()
+
+	var current *version.Version
+	for _, release := range releases {
+		tagName := release.GetTagName()
+		if !filterF(tagName) {
+			fr.Malformeds = append(fr.Malformeds, tagName)
+			fr.Filtered = append(fr.Filtered, tagName)
+			continue
+		}
+
+		r := fr.addRelease(tagName, fixF(tagName))
+		r.Date = release.GetPublishedAt().Time
+		r.URL = release.GetHTMLURL()
+		r.Commit = release.GetTargetCommitish()
+		r.Notes = release.GetBody()
+		for _, a := range release.Assets {
+			r.Assets = append(r.Assets, &Asset{
+				Name:        a.GetName(),
+				URL:         a.GetBrowserDownloadURL(),
+				Size:        int64(a.GetSize()),
+				ContentType: a.GetContentType(),
+			})
+		}
+
+		v, err := version.NewVersion(r.Version)
+		if err != nil {
+			continue
+		}
+
+		if current == nil || v.GreaterThan(current) {
+			current = v
+			trailers := parseTrailers(release.GetBody())
+			fr.Meta = &Meta{
+				Message:    release.GetName(),
+				URL:        release.GetHTMLURL(),
+				MinVersion: trailers[MinVersionTrailer],
+				EOL:        trailers[EOLTrailer],
+			}
+		}
+	}
+
+	return fr, nil
+}
+
+// FetchReleaseNotes implements ChangelogSource. It returns release body
+// of each release as release note.
+func (g *GithubRelease) FetchReleaseNotes() ([]*ReleaseNote, error) {
+	releases, err := g.listReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	fixF := g.fixVersionStrFunc()
+	filterF := g.tagFilterFunc()
+
+	var notes []*ReleaseNote
+	for _, release := range releases {
+		if !filterF(release.GetTagName()) {
+			continue
+		}
+
+		notes = append(notes, &ReleaseNote{
+			Version: fixF(release.GetTagName()),
+			Title:   release.GetName(),
+			Body:    release.GetBody(),
+			URL:     release.GetHTMLURL(),
+			Date:    release.GetPublishedAt().Time,
+		})
+	}
+
+	return notes, nil
+}
+
+// listReleases lists releases on GitHub. Drafts are excluded,
+// and pre-releases are excluded unless Prerelease is true.
+func (g *GithubRelease) listReleases() ([]*github.RepositoryRelease, error) {
+
+	// Create a client
+	client := newGithubClient(g.URL, g.Token)
+	opt := &github.ListOptions{PerPage: 100}
+
+	var releases []*github.RepositoryRelease
+	for {
+		var page []*github.RepositoryRelease
+		var resp *github.Response
+		err := retryPolicyOf(g.Retry).do(func() error {
+			var err error
+			page, resp, err = client.Repositories.ListReleases(context.Background(), g.Owner, g.Repository, opt)
+			if err != nil {
+				return githubError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != 200 {
+			return nil, &StatusError{URL: resp.Request.URL.String(), StatusCode: resp.StatusCode}
+		}
+
+		releases = append(releases, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	var res []*github.RepositoryRelease
+	for _, release := range releases {
+		if release.GetDraft() || (release.GetPrerelease() && !g.Prerelease) {
+			continue
+		}
+		res = append(res, release)
+	}
+
+	return res, nil
+}
+
+// parseTrailers parses `Key: value` lines in release body. Only
+// MinVersionTrailer and EOLTrailer are returned.
+func parseTrailers(body string) map[string]string {
+	trailers := make(map[string]string)
+
+	sc := bufio.NewScanner(strings.NewReader(body))
+	for sc.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(sc.Text()), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		for _, k := range []string{MinVersionTrailer, EOLTrailer} {
+			if strings.EqualFold(key, k) {
+				trailers[k] = strings.TrimSpace(parts[1])
+			}
+		}
+	}
+
+	return trailers
+}
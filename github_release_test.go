@@ -0,0 +1,161 @@
+package latest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+// fakeGithubServer returns fake GitHub API server which responds
+// fixture on the path.
+func fakeGithubServer(path, fixture string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle(path, fakeHandler(fixture))
+	return httptest.NewServer(mux)
+}
+
+func TestGithubRelease_implement(t *testing.T) {
+	var _ Source = &GithubRelease{}
+}
+
+func TestGithubReleaseValidate(t *testing.T) {
+
+	tests := []struct {
+		GithubRelease *GithubRelease
+		expectErr     bool
+	}{
+		{
+			GithubRelease: &GithubRelease{Owner: "tcnksm", Repository: "ghr"},
+			expectErr:     false,
+		},
+		{
+			GithubRelease: &GithubRelease{Owner: "tcnksm"},
+			expectErr:     true,
+		},
+		{
+			GithubRelease: &GithubRelease{Repository: "ghr"},
+			expectErr:     true,
+		},
+	}
+
+	for i, tt := range tests {
+		err := tt.GithubRelease.Validate()
+		if tt.expectErr == (err == nil) {
+			t.Fatalf("#%d Validate() expects err == nil to eq %t", i, tt.expectErr)
+		}
+	}
+}
+
+func TestGithubReleaseFetch(t *testing.T) {
+	ts := fakeGithubServer("/repos/tcnksm/ghr/releases", "test-fixtures/github_releases.json")
+	defer ts.Close()
+
+	tests := []struct {
+		prerelease     bool
+		expectCurrent  string
+		expectVersions int
+		expectMessage  string
+		expectMin      string
+		expectEOL      string
+	}{
+		{
+			expectCurrent:  "0.2.0",
+			expectVersions: 2,
+			expectMessage:  "Parallel upload",
+			expectMin:      "0.1.2",
+			expectEOL:      "2015-06-01",
+		},
+		{
+			prerelease:     true,
+			expectCurrent:  "0.3.0-rc1",
+			expectVersions: 3,
+			expectMessage:  "v0.3.0-rc1",
+		},
+	}
+
+	for i, tt := range tests {
+		g := &GithubRelease{
+			Owner:             "tcnksm",
+			Repository:        "ghr",
+			FixVersionStrFunc: DeleteFrontV(),
+			Prerelease:        tt.prerelease,
+			URL:               ts.URL,
+		}
+
+		fr, err := g.Fetch()
+		if err != nil {
+			t.Fatalf("#%d Fetch() expects error:%q to be nil", i, err.Error())
+		}
+
+		versions := fr.Versions
+		if len(versions) != tt.expectVersions {
+			t.Fatalf("#%d Fetch() expects number of versions %d to be %d", i, len(versions), tt.expectVersions)
+		}
+
+		sort.Sort(version.Collection(versions))
+		current := versions[len(versions)-1].String()
+		if current != tt.expectCurrent {
+			t.Fatalf("#%d Fetch() expects %s to be %s", i, current, tt.expectCurrent)
+		}
+
+		if fr.Meta.Message != tt.expectMessage {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.Message, tt.expectMessage)
+		}
+
+		if fr.Meta.MinVersion != tt.expectMin || fr.Meta.EOL != tt.expectEOL {
+			t.Fatalf("#%d Fetch() expects MinVersion, EOL (%q, %q) to be (%q, %q)", i,
+				fr.Meta.MinVersion, fr.Meta.EOL, tt.expectMin, tt.expectEOL)
+		}
+	}
+}
+
+func TestGithubReleaseFetch_token(t *testing.T) {
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		fakeHandler("test-fixtures/github_releases.json").ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	g := &GithubRelease{Owner: "tcnksm", Repository: "ghr", URL: ts.URL, Token: "secret"}
+	if _, err := g.Fetch(); err != nil {
+		t.Fatalf("Fetch() expects error:%q to be nil", err.Error())
+	}
+
+	if auth != "token secret" {
+		t.Fatalf("Fetch() expects Authorization header %q to be %q", auth, "token secret")
+	}
+}
+
+func TestGithubReleaseFetch_pagination(t *testing.T) {
+	var perPage string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = r.URL.Query().Get("per_page")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"tag_name": "v0.1.0"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2&per_page=100>; rel="next"`, ts.URL, r.URL.Path))
+		fmt.Fprint(w, `[{"tag_name": "v0.2.0"}]`)
+	}))
+	defer ts.Close()
+
+	g := &GithubRelease{Owner: "tcnksm", Repository: "ghr", URL: ts.URL}
+	fr, err := g.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() expects error:%q to be nil", err.Error())
+	}
+
+	if len(fr.Versions) != 2 {
+		t.Fatalf("Fetch() expects versions %v to be on 2 pages", fr.Versions)
+	}
+
+	if perPage != "100" {
+		t.Fatalf("Fetch() expects per_page %q to be 100", perPage)
+	}
+}
@@ -1,9 +1,199 @@
 package latest
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGithubTag_implement(t *testing.T) {
 	var _ Source = &GithubTag{}
 }
+
+func TestSplitModulePath(t *testing.T) {
+	tests := []struct {
+		path         string
+		expectPrefix string
+		expectMajor  int
+	}{
+		{"cli", "cli/", 0},
+		{"tools/gen/v2", "tools/gen/", 2},
+		{"tools/gen/v1", "tools/gen/v1/", 0},
+		{"v2", "", 2},
+		{"api/v10/", "api/", 10},
+		{".", "", 0},
+	}
+
+	for i, tt := range tests {
+		prefix, major := splitModulePath(tt.path)
+		if prefix != tt.expectPrefix || major != tt.expectMajor {
+			t.Fatalf("#%d expects %q, %d to be %q, %d", i, prefix, major, tt.expectPrefix, tt.expectMajor)
+		}
+	}
+}
+
+func TestGithubTagFetch_path(t *testing.T) {
+	ts := fakeGithubServer("/repos/tcnksm/mono/tags", "test-fixtures/github_monorepo_tags.json")
+	defer ts.Close()
+
+	b, err := ioutil.ReadFile("test-fixtures/github_monorepo_tags.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tags []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(b, &tags); err != nil {
+		t.Fatal(err)
+	}
+	var tagNames []string
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+
+	tests := []struct {
+		path           string
+		filter         TagFilterFunc
+		expectVersions []string
+	}{
+		// Without Path, components are mixed
+		{"", nil, []string{"1.4.0", "1.3.0", "2.0.0", "1.0.0", "2.1.0", "3.0.0+incompatible"}},
+		{"cli", nil, []string{"1.4.0", "1.3.0"}},
+		{"cli/v2", nil, []string{"2.0.0"}},
+		{"sdk", nil, []string{"0.9.2"}},
+		{"tools/gen", nil, []string{"1.9.0"}},
+		{"tools/gen/v2", nil, []string{"2.0.1"}},
+		{"v2", nil, []string{"2.1.0"}},
+		{".", nil, []string{"1.0.0", "3.0.0+incompatible"}},
+		{"cli", MatchGlob("v1.3.*"), []string{"1.3.0"}},
+	}
+
+	for i, tt := range tests {
+		g := &GithubTag{
+			Owner:             "tcnksm",
+			Repository:        "mono",
+			URL:               ts.URL,
+			Path:              tt.path,
+			TagFilterFunc:     tt.filter,
+			FixVersionStrFunc: DeleteProjectPrefix("cli"),
+		}
+
+		fr, err := g.Fetch()
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		var got []string
+		for _, v := range fr.Versions {
+			got = append(got, v.String())
+		}
+
+		if !reflect.DeepEqual(got, tt.expectVersions) {
+			t.Fatalf("#%d expects %v to be %v", i, got, tt.expectVersions)
+		}
+
+		// Filtered tags are recorded by their real names
+		for _, name := range fr.Filtered {
+			if !containsString(tagNames, name) || !containsString(fr.Malformeds, name) {
+				t.Fatalf("#%d expects filtered %q to be tag name in Malformeds", i, name)
+			}
+		}
+		if len(fr.Filtered)+len(fr.Releases) != len(tagNames) {
+			t.Fatalf("#%d expects every tag to be filtered %v or released", i, fr.Filtered)
+		}
+	}
+}
+
+func TestGithubTagFetch_commitDate(t *testing.T) {
+	var requests int
+	commit := fakeHandler("test-fixtures/github_commit.json")
+	mux := http.NewServeMux()
+	mux.Handle("/repos/tcnksm/ghr/tags", fakeHandler("test-fixtures/github_tags.json"))
+	mux.HandleFunc("/repos/tcnksm/ghr/git/commits/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		commit.ServeHTTP(w, r)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	tests := []struct {
+		commitDate bool
+		expect     time.Time
+	}{
+		{commitDate: false},
+		{commitDate: true, expect: time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for i, tt := range tests {
+		g := &GithubTag{
+			Owner:      "tcnksm",
+			Repository: "ghr",
+			URL:        ts.URL,
+			CommitDate: tt.commitDate,
+		}
+
+		requests = 0
+		fr, err := g.Fetch()
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if requests != 0 {
+			t.Fatalf("#%d expects commit not to be fetched on Fetch: %d requests", i, requests)
+		}
+
+		for _, r := range fr.Releases {
+			date, err := r.PublishDate()
+			if err != nil {
+				t.Fatalf("#%d expects err to be nil: %s", i, err)
+			}
+			if !date.Equal(tt.expect) || !r.Date.Equal(tt.expect) {
+				t.Fatalf("#%d expects %s date %s to be %s", i, r.Name, date, tt.expect)
+			}
+		}
+	}
+}
+
+func TestCheck_minAgeCommitDate(t *testing.T) {
+	var requests int
+	commit := fakeHandler("test-fixtures/github_commit.json")
+	mux := http.NewServeMux()
+	mux.Handle("/repos/tcnksm/ghr/tags", fakeHandler("test-fixtures/github_tags.json"))
+	mux.HandleFunc("/repos/tcnksm/ghr/git/commits/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		commit.ServeHTTP(w, r)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	defer func(f func() time.Time) { timeNow = f }(timeNow)
+	timeNow = func() time.Time { return time.Date(2015, 6, 10, 0, 0, 0, 0, time.UTC) }
+
+	c := &Checker{
+		Source: &GithubTag{
+			Owner:      "tcnksm",
+			Repository: "ghr",
+			URL:        ts.URL,
+			CommitDate: true,
+		},
+		MinAge: 24 * time.Hour,
+	}
+
+	res, err := c.Check("0.1.0")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	if res.Current == "" || res.CurrentAge == 0 {
+		t.Fatalf("expects Current and CurrentAge to be set: %#v", res)
+	}
+
+	// Only the newest tag is mature enough, so only its commit is fetched
+	if requests != 1 {
+		t.Fatalf("expects 1 commit request: %d", requests)
+	}
+}
@@ -0,0 +1,256 @@
+package latest
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// DefaultGoProxyURL is URL of Go module proxy (https://proxy.golang.org).
+const DefaultGoProxyURL = "https://proxy.golang.org"
+
+// GoModule is used to fetch versions of Go module from Go module proxy
+// (GOPROXY protocol). Versions retracted by `retract` directives in
+// go.mod of the latest version are marked as retracted with their
+// rationale, so they are never Current.
+//
+//	goModule := &latest.GoModule{
+//	    Module: "github.com/tcnksm/ghr",
+//	}
+type GoModule struct {
+	// Module is module path (e.g., `github.com/tcnksm/ghr`). It MUST be set.
+	Module string
+
+	// URL is Go module proxy URL. By default, DefaultGoProxyURL is used.
+	URL string
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
+}
+
+// goRetract is a `retract` directive in go.mod. Low and High are the
+// same for a single version.
+type goRetract struct {
+	Low, High string
+	Rationale string
+}
+
+func (g *GoModule) url() string {
+	if g.URL == "" {
+		return DefaultGoProxyURL
+	}
+
+	return strings.TrimSuffix(g.URL, "/")
+}
+
+func (g *GoModule) Validate() error {
+
+	if len(g.Module) == 0 {
+		return validationErrorf("Module must be set")
+	}
+
+	if _, err := url.Parse(g.url()); err != nil {
+		return validationErrorf("%s is invalid URL: %s", g.url(), err.Error())
+	}
+
+	return nil
+}
+
+func (g *GoModule) Fetch() (*FetchResponse, error) {
+
+	fr := newFetchResponse()
+
+	list, err := g.get("list")
+	if err != nil {
+		return fr, err
+	}
+
+	for _, line := range strings.Split(string(list), "\n") {
+		if verStr := strings.TrimSpace(line); verStr != "" {
+			fr.addRelease(verStr, verStr)
+		}
+	}
+
+	if len(fr.Versions) == 0 {
+		return fr, noVersionsError("version info is not found for %s", g.Module)
+	}
+
+	// Retractions are read from go.mod of the latest version
+	latestV := goLatestVersion(fr.Versions)
+	mod, err := g.get(goEscape(latestV.Original()) + ".mod")
+	if err != nil {
+		return fr, err
+	}
+
+	for _, rt := range parseGoModRetracts(mod) {
+		low, err := version.NewVersion(rt.Low)
+		if err != nil {
+			continue
+		}
+		high, err := version.NewVersion(rt.High)
+		if err != nil {
+			continue
+		}
+
+		for _, r := range fr.Releases {
+			v, err := version.NewVersion(r.Version)
+			if err != nil || v.LessThan(low) || v.GreaterThan(high) {
+				continue
+			}
+			r.Retracted = true
+			r.Reason = rt.Rationale
+		}
+	}
+
+	return fr, nil
+}
+
+// get requests file of the module on Go module proxy.
+func (g *GoModule) get(file string) ([]byte, error) {
+	u := g.url() + "/" + goEscape(g.Module) + "/@v/" + file
+
+	resp, err := httpGet(u, "", g.Retry)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &NetworkError{URL: u, Err: err}
+	}
+
+	return b, nil
+}
+
+// goLatestVersion returns the greatest release version. If there is no
+// release version, it returns the greatest pre-release version, like
+// `go` command does.
+func goLatestVersion(vs []*version.Version) *version.Version {
+	var latest, latestPre *version.Version
+	for _, v := range vs {
+		if v.Prerelease() != "" {
+			if latestPre == nil || v.GreaterThan(latestPre) {
+				latestPre = v
+			}
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+
+	if latest == nil {
+		return latestPre
+	}
+	return latest
+}
+
+// goEscape escapes module path or version for Go module proxy. Upper
+// case letter is replaced with `!` and its lower case.
+func goEscape(s string) string {
+	var buf bytes.Buffer
+	for _, r := range s {
+		if 'A' <= r && r <= 'Z' {
+			buf.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// parseGoModRetracts parses `retract` directives in go.mod. Rationale is
+// comment on the same line or comments just before the directive (or
+// before the block when entry in block has no comment).
+func parseGoModRetracts(data []byte) []*goRetract {
+	var (
+		retracts []*goRetract
+		comments []string
+		block    []string
+		inBlock  bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, comment := splitGoModComment(scanner.Text())
+
+		switch {
+		case line == "" && comment != "":
+			comments = append(comments, comment)
+			continue
+		case line == "":
+			comments = nil
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			if rt := parseGoRetract(line); rt != nil {
+				rt.Rationale = goRationale(comments, comment, block)
+				retracts = append(retracts, rt)
+			}
+		case line == "retract (":
+			inBlock, block = true, comments
+			if comment != "" {
+				block = append(block, comment)
+			}
+		case strings.HasPrefix(line, "retract "):
+			if rt := parseGoRetract(strings.TrimPrefix(line, "retract ")); rt != nil {
+				rt.Rationale = goRationale(comments, comment, nil)
+				retracts = append(retracts, rt)
+			}
+		}
+
+		comments = nil
+	}
+
+	return retracts
+}
+
+// splitGoModComment splits line of go.mod into directive and comment.
+func splitGoModComment(line string) (string, string) {
+	comment := ""
+	if i := strings.Index(line, "//"); i >= 0 {
+		line, comment = line[:i], strings.TrimSpace(line[i+2:])
+	}
+	return strings.TrimSpace(line), comment
+}
+
+// parseGoRetract parses `v1.0.0` or `[v1.0.0, v1.9.9]`.
+func parseGoRetract(arg string) *goRetract {
+	arg = strings.TrimSpace(arg)
+	if strings.HasPrefix(arg, "[") && strings.HasSuffix(arg, "]") {
+		bounds := strings.Split(arg[1:len(arg)-1], ",")
+		if len(bounds) != 2 {
+			return nil
+		}
+		return &goRetract{
+			Low:  strings.TrimSpace(bounds[0]),
+			High: strings.TrimSpace(bounds[1]),
+		}
+	}
+
+	if arg == "" || strings.ContainsAny(arg, " \t") {
+		return nil
+	}
+
+	return &goRetract{Low: arg, High: arg}
+}
+
+// goRationale returns rationale of retract directive from comments before
+// it, comment on the same line or comments of the block in this order.
+func goRationale(before []string, suffix string, block []string) string {
+	cs := before
+	if suffix != "" {
+		cs = append(cs, suffix)
+	}
+	if len(cs) == 0 {
+		cs = block
+	}
+	return strings.Join(cs, " ")
+}
@@ -0,0 +1,117 @@
+package latest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGoModule_implement(t *testing.T) {
+	var _ Source = &GoModule{}
+}
+
+func TestGoModuleValidate(t *testing.T) {
+
+	tests := []struct {
+		GoModule  *GoModule
+		expectErr bool
+	}{
+		{
+			GoModule:  &GoModule{Module: "github.com/tcnksm/ghr"},
+			expectErr: false,
+		},
+		{
+			GoModule:  &GoModule{},
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		err := tt.GoModule.Validate()
+		if tt.expectErr == (err == nil) {
+			t.Fatalf("#%d Validate() expects err == nil to eq %t", i, tt.expectErr)
+		}
+	}
+}
+
+func TestGoModuleFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/github.com/!tcnksm/ghr/@v/list", fakeHandler("test-fixtures/gomod/list"))
+	mux.Handle("/github.com/!tcnksm/ghr/@v/v1.3.0.mod", fakeHandler("test-fixtures/gomod/v1.3.0.mod"))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	g := &GoModule{
+		Module: "github.com/Tcnksm/ghr",
+		URL:    ts.URL,
+	}
+
+	fr, err := g.Fetch()
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	if len(fr.Versions) != 6 {
+		t.Fatalf("expects %v to have 6 versions", fr.Versions)
+	}
+
+	expect := map[string]string{
+		"v1.0.0": "Published accidentally.",
+		"v1.1.0": "Broken build.",
+		"v1.2.0": "Upload fails on large files.",
+		"v1.2.1": "Upload fails on large files.",
+	}
+
+	retracted := map[string]string{}
+	for _, r := range fr.Releases {
+		if r.Retracted {
+			retracted[r.Version] = r.Reason
+		}
+	}
+
+	if !reflect.DeepEqual(retracted, expect) {
+		t.Fatalf("expects %v to be %v", retracted, expect)
+	}
+}
+
+func TestParseGoModRetracts(t *testing.T) {
+	tests := []struct {
+		in     string
+		expect []*goRetract
+	}{
+		{
+			in:     "retract v1.0.0 // Broken.\n",
+			expect: []*goRetract{{"v1.0.0", "v1.0.0", "Broken."}},
+		},
+		{
+			in:     "// Line one.\n// Line two.\nretract [v1.0.0, v1.0.5]\n",
+			expect: []*goRetract{{"v1.0.0", "v1.0.5", "Line one. Line two."}},
+		},
+		{
+			in:     "// Not rationale.\n\nretract v1.0.0\n",
+			expect: []*goRetract{{"v1.0.0", "v1.0.0", ""}},
+		},
+		{
+			in:     "// Block.\nretract (\n\tv1.0.0\n\tv1.1.0 // Own.\n)\n",
+			expect: []*goRetract{{"v1.0.0", "v1.0.0", "Block."}, {"v1.1.0", "v1.1.0", "Own."}},
+		},
+		{
+			in:     "module example.com/m\n\nrequire example.com/dep v1.0.0\n",
+			expect: nil,
+		},
+	}
+
+	for i, tt := range tests {
+		got := parseGoModRetracts([]byte(tt.in))
+		if !reflect.DeepEqual(got, tt.expect) {
+			t.Fatalf("#%d expects %v to be %v", i, got, tt.expect)
+		}
+	}
+}
+
+func TestGoEscape(t *testing.T) {
+	if got := goEscape("github.com/BurntSushi/toml"); got != "github.com/!burnt!sushi/toml" {
+		t.Fatalf("expects %q to be github.com/!burnt!sushi/toml", got)
+	}
+}
@@ -8,12 +8,16 @@ import (
 )
 
 func fakeServer(fixture string) *httptest.Server {
-	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	return httptest.NewServer(fakeHandler(fixture))
+}
+
+func fakeHandler(fixture string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		f, err := os.Open(fixture)
 		if err != nil {
 			// Should not reach here
 			panic(err)
 		}
 		io.Copy(w, f)
-	}))
+	})
 }
@@ -2,14 +2,9 @@ package latest
 
 import (
 	"bytes"
-	"fmt"
 	"io"
 	"io/ioutil"
-	"net"
-	"net/http"
 	"net/url"
-
-	"github.com/hashicorp/go-version"
 )
 
 // HTML is used to fetch version information from a single HTML page.
@@ -20,7 +15,18 @@ type HTML struct {
 	// Scrap is used to scrap a single HTML page and extract version information.
 	// See more about HTMLScrap interface.
 	// By default, it does nothing, just return HTML contents.
+	// SelectorScrap (CSS selector) and RegexpScrap (regular expression)
+	// are provided as built-in HTMLScrap.
 	Scrap HTMLScrap
+
+	// Client is information about running client. If it's set, it's sent
+	// as query parameters so that server can tailor response. By default,
+	// nothing is sent.
+	Client *ClientInfo
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
 }
 
 // HTMLScrap is used to scrap a single HTML page and extract version information.
@@ -54,12 +60,12 @@ func (h *HTML) scrap() HTMLScrap {
 func (h *HTML) Validate() error {
 
 	if len(h.URL) == 0 {
-		return fmt.Errorf("URL must be set")
+		return validationErrorf("URL must be set")
 	}
 
 	// Check URL can be parsed
 	if _, err := url.Parse(h.URL); err != nil {
-		return fmt.Errorf("%s is invalid URL: %s", h.URL, err.Error())
+		return validationErrorf("%s is invalid URL: %s", h.URL, err.Error())
 	}
 
 	return nil
@@ -71,52 +77,26 @@ func (h *HTML) Fetch() (*FetchResponse, error) {
 
 	// URL is validated before call
 	u, _ := url.Parse(h.URL)
+	h.Client.addQuery(u)
 
-	// Create a new request
-	req, err := http.NewRequest("GET", u.String(), nil)
+	resp, err := httpGet(u.String(), "text/html", h.Retry)
 	if err != nil {
 		return fr, err
 	}
-	req.Header.Add("Accept", "application/json")
-
-	// Create client
-	t := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		Dial: func(n, a string) (net.Conn, error) {
-			return net.DialTimeout(n, a, defaultDialTimeout)
-		},
-	}
-
-	client := &http.Client{
-		Transport: t,
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return fr, err
-	}
-
-	if resp.StatusCode != 200 {
-		return fr, fmt.Errorf("unknown status: %d", resp.StatusCode)
-	}
+	defer resp.Body.Close()
 
 	scrap := h.scrap()
 	verStrs, meta, err := scrap.Exec(resp.Body)
 	if err != nil {
-		return fr, err
+		return fr, wrapParseError(h.URL, err)
 	}
 
 	if len(verStrs) == 0 {
-		return fr, fmt.Errorf("version info is not found on %s", h.URL)
+		return fr, noVersionsError("version info is not found on %s", h.URL)
 	}
 
 	for _, verStr := range verStrs {
-		v, err := version.NewVersion(verStr)
-		if err != nil {
-			fr.Malformeds = append(fr.Malformeds, verStr)
-			continue
-		}
-		fr.Versions = append(fr.Versions, v)
+		fr.addRelease(verStr, verStr)
 	}
 
 	fr.Meta = meta
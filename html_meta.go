@@ -1,10 +1,11 @@
 package latest
 
 import (
-	"fmt"
 	"io"
 	"strings"
 
+	"github.com/hashicorp/go-version"
+
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
 )
@@ -22,16 +23,53 @@ type HTMLMeta struct {
 	// written in HTML meta tag content field. HTMLMeta use this to
 	// extract version information.
 	Name string
+
+	// Channel is release channel which you want to check (e.g., `beta`).
+	// Only meta tags which have the same data-channel attribute are used.
+	// By default, it is `stable` and meta tags which don't have
+	// data-channel attribute are also regarded as `stable`.
+	Channel string
+
+	// Client is information about running client. If it's set, it's sent
+	// as query parameters so that server can tailor response. If its
+	// Channel is empty, Channel above is sent. Otherwise it MUST be the
+	// same as Channel above. By default, nothing is sent.
+	Client *ClientInfo
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
 }
 
 func (hm *HTMLMeta) newHTML() *HTML {
 	return &HTML{
-		URL:   hm.URL,
-		Scrap: &metaTagScrap{Name: hm.Name},
+		URL:    hm.URL,
+		Scrap:  &metaTagScrap{Name: hm.Name, Channel: hm.Channel},
+		Client: hm.client(),
+		Retry:  hm.Retry,
+	}
+}
+
+func (hm *HTMLMeta) client() *ClientInfo {
+	if hm.Client == nil || hm.Client.Channel != "" {
+		return hm.Client
 	}
+
+	c := *hm.Client
+	c.Channel = hm.Channel
+	return &c
 }
 
 func (hm *HTMLMeta) Validate() error {
+	// Meta tags are selected by Channel, so different channel sent to
+	// server would never match
+	if hm.Client != nil && hm.Client.Channel != "" {
+		scrap := &metaTagScrap{Channel: hm.Channel}
+		if hm.Client.Channel != scrap.channel() {
+			return validationErrorf("Client.Channel %q must be the same as Channel %q", hm.Client.Channel, scrap.channel())
+		}
+	}
+
 	return hm.newHTML().Validate()
 }
 
@@ -39,42 +77,82 @@ func (hm *HTMLMeta) Fetch() (*FetchResponse, error) {
 	return hm.newHTML().Fetch()
 }
 
+// defaultChannel is release channel used when it's not specified.
+const defaultChannel = "stable"
+
 type metaTagScrap struct {
-	Name string
+	Name    string
+	Channel string
+}
+
+func (mt *metaTagScrap) channel() string {
+	if mt.Channel == "" {
+		return defaultChannel
+	}
+
+	return mt.Channel
 }
 
+// tagInside is information written in a single go-latest meta tag.
 type tagInside struct {
-	name    string
-	prefix  string
+	product string
 	version string
+	channel string
 	meta    *Meta
 }
 
+// Exec returns all versions advertised by meta tags for the product and
+// channel. Meta is taken from the meta tag of the greatest version.
 func (mt *metaTagScrap) Exec(r io.Reader) ([]string, *Meta, error) {
 
+	var verStrs []string
+	var current *version.Version
+	meta := &Meta{}
+
 	z := html.NewTokenizer(r)
 
 	for {
 		switch z.Next() {
 		case html.ErrorToken:
-			return []string{}, &Meta{}, fmt.Errorf("meta tag for %s is not found", mt.Name)
+			if len(verStrs) == 0 {
+				return []string{}, &Meta{}, noVersionsError("meta tag for %s is not found", mt.Name)
+			}
+			return verStrs, meta, nil
 
 		case html.StartTagToken, html.SelfClosingTagToken:
 			tok := z.Token()
-			if tok.DataAtom == atom.Meta {
-				product, version, message := attrAnalizer(tok.Attr)
-				// Return first founded version.
-				// Assumes that mata tag exist only one for each product
-				if product == mt.Name {
-					return []string{version}, &Meta{Message: message}, nil
-				}
+			if tok.DataAtom != atom.Meta {
+				continue
+			}
+
+			tag, ok := attrAnalizer(tok.Attr)
+			if !ok || tag.product != mt.Name || tag.channel != mt.channel() {
+				continue
+			}
+			verStrs = append(verStrs, tag.version)
+
+			v, err := version.NewVersion(tag.version)
+			if err != nil {
+				continue
+			}
+
+			if current == nil || v.GreaterThan(current) {
+				current, meta = v, tag.meta
 			}
 		}
 	}
 }
 
-func attrAnalizer(attrs []html.Attribute) (product, version, message string) {
+// attrAnalizer extracts information from attributes of meta tag.
+// It returns false if it's not go-latest meta tag.
+func attrAnalizer(attrs []html.Attribute) (*tagInside, bool) {
+
+	tag := &tagInside{
+		channel: defaultChannel,
+		meta:    &Meta{},
+	}
 
+	var isTarget bool
 	for _, a := range attrs {
 
 		if a.Namespace != "" {
@@ -83,25 +161,50 @@ func attrAnalizer(attrs []html.Attribute) (product, version, message string) {
 
 		switch a.Key {
 		case "name":
-			if a.Val != MetaTagName {
-				break
-			}
+			isTarget = a.Val == MetaTagName
 
 		case "content":
-			parts := strings.SplitN(strings.TrimSpace(a.Val), " ", 3)
-			if len(parts) < 2 {
-				break
+			tag.product, tag.version, tag.meta.Message = contentAnalizer(a.Val)
+
+		case "data-channel":
+			if a.Val != "" {
+				tag.channel = a.Val
 			}
 
-			product = parts[0]
-			version = parts[1]
+		case "data-url":
+			tag.meta.URL = a.Val
 
-			// message is optional
-			if len(parts) == 3 {
-				message = parts[2]
-			}
+		case "data-min-version":
+			tag.meta.MinVersion = a.Val
+
+		case "data-eol":
+			tag.meta.EOL = a.Val
 		}
 	}
 
+	if !isTarget || tag.product == "" {
+		return nil, false
+	}
+
+	return tag, true
+}
+
+// contentAnalizer splits content with `product version message` format.
+// message is optional. If content doesn't have product and version,
+// it returns empty strings.
+func contentAnalizer(content string) (product, version, message string) {
+	parts := strings.SplitN(strings.TrimSpace(content), " ", 3)
+	if len(parts) < 2 {
+		return
+	}
+
+	product = parts[0]
+	version = parts[1]
+
+	// message is optional
+	if len(parts) == 3 {
+		message = parts[2]
+	}
+
 	return
 }
@@ -1,26 +1,95 @@
 package latest
 
 import (
+	"errors"
 	"net/http/httptest"
+	"sort"
 	"testing"
+
+	"github.com/hashicorp/go-version"
 )
 
 func TestHTMLMeta_implement(t *testing.T) {
 	var _ Source = &HTMLMeta{}
 }
 
+func TestHTMLMetaValidate(t *testing.T) {
+	tests := []struct {
+		htmlMeta  *HTMLMeta
+		expectErr bool
+	}{
+		{
+			htmlMeta:  &HTMLMeta{URL: "http://example.com", Name: "reduce-worker"},
+			expectErr: false,
+		},
+		{
+			htmlMeta:  &HTMLMeta{URL: "http://example.com", Name: "reduce-worker", Client: &ClientInfo{}},
+			expectErr: false,
+		},
+		{
+			htmlMeta:  &HTMLMeta{URL: "http://example.com", Name: "reduce-worker", Client: &ClientInfo{Channel: "stable"}},
+			expectErr: false,
+		},
+		{
+			htmlMeta:  &HTMLMeta{URL: "http://example.com", Name: "reduce-worker", Channel: "beta", Client: &ClientInfo{Channel: "beta"}},
+			expectErr: false,
+		},
+		{
+			htmlMeta:  &HTMLMeta{URL: "http://example.com", Name: "reduce-worker", Client: &ClientInfo{Channel: "beta"}},
+			expectErr: true,
+		},
+		{
+			htmlMeta:  &HTMLMeta{URL: "http://example.com", Name: "reduce-worker", Channel: "beta", Client: &ClientInfo{Channel: "stable"}},
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		err := tt.htmlMeta.Validate()
+		if (err != nil) != tt.expectErr {
+			t.Fatalf("#%d Validate() expects error to be %t: %v", i, tt.expectErr, err)
+		}
+		if err != nil && !errors.Is(err, ErrValidation) {
+			t.Fatalf("#%d Validate() expects %v to be ErrValidation", i, err)
+		}
+	}
+}
+
 func TestHTMLMetaFetch(t *testing.T) {
 	tests := []struct {
-		name          string
-		testServer    *httptest.Server
-		expectCurrent string
-		expectMessage string
+		name           string
+		channel        string
+		testServer     *httptest.Server
+		expectCurrent  string
+		expectVersions int
+		expectMessage  string
+		expectURL      string
+		expectMin      string
+		expectEOL      string
 	}{
 		{
-			name:          "reduce-worker",
-			testServer:    fakeServer("test-fixtures/meta.html"),
-			expectCurrent: "1.2.1",
-			expectMessage: "New version include security update",
+			name:           "reduce-worker",
+			testServer:     fakeServer("test-fixtures/meta.html"),
+			expectCurrent:  "1.2.1",
+			expectVersions: 2,
+			expectMessage:  "New version include security update",
+		},
+		{
+			name:           "reduce-worker",
+			testServer:     fakeServer("test-fixtures/meta_channel.html"),
+			expectCurrent:  "1.2.1",
+			expectVersions: 2,
+			expectMessage:  "New version include security update",
+			expectURL:      "http://example.com/releases/1.2.1",
+			expectMin:      "1.0.0",
+			expectEOL:      "2016-01-01",
+		},
+		{
+			name:           "reduce-worker",
+			channel:        "beta",
+			testServer:     fakeServer("test-fixtures/meta_channel.html"),
+			expectCurrent:  "1.3.0-beta2",
+			expectVersions: 2,
 		},
 	}
 
@@ -29,8 +98,9 @@ func TestHTMLMetaFetch(t *testing.T) {
 		defer ts.Close()
 
 		h := &HTMLMeta{
-			URL:  ts.URL,
-			Name: tt.name,
+			URL:     ts.URL,
+			Name:    tt.name,
+			Channel: tt.channel,
 		}
 
 		fr, err := h.Fetch()
@@ -39,11 +109,12 @@ func TestHTMLMetaFetch(t *testing.T) {
 		}
 
 		versions := fr.Versions
-		if len(versions) == 0 {
-			t.Fatalf("#%d Fetch() expects number of versions found from HTML not to be 0", i)
+		if len(versions) != tt.expectVersions {
+			t.Fatalf("#%d Fetch() expects number of versions %d to be %d", i, len(versions), tt.expectVersions)
 		}
 
-		current := versions[0].String()
+		sort.Sort(version.Collection(versions))
+		current := versions[len(versions)-1].String()
 		if current != tt.expectCurrent {
 			t.Fatalf("#%d Fetch() expects %s to be %s", i, current, tt.expectCurrent)
 		}
@@ -52,6 +123,33 @@ func TestHTMLMetaFetch(t *testing.T) {
 		if message != tt.expectMessage {
 			t.Fatalf("#%d Fetch() expects %q to be %q", i, message, tt.expectMessage)
 		}
+
+		if fr.Meta.URL != tt.expectURL {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.URL, tt.expectURL)
+		}
+
+		if fr.Meta.MinVersion != tt.expectMin {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.MinVersion, tt.expectMin)
+		}
+
+		if fr.Meta.EOL != tt.expectEOL {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.EOL, tt.expectEOL)
+		}
 	}
 
 }
+
+func TestHTMLMetaFetch_notFound(t *testing.T) {
+	ts := fakeServer("test-fixtures/meta_channel.html")
+	defer ts.Close()
+
+	h := &HTMLMeta{
+		URL:     ts.URL,
+		Name:    "reduce-worker",
+		Channel: "nightly",
+	}
+
+	if _, err := h.Fetch(); err == nil {
+		t.Fatalf("Fetch() expects error")
+	}
+}
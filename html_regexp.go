@@ -0,0 +1,98 @@
+package latest
+
+import (
+	"io"
+	"io/ioutil"
+	"regexp"
+)
+
+// RegexpScrap is HTMLScrap which extracts version information from
+// HTML contents by regular expression. Version is extracted from capture
+// group named `version` or, if there is no such group, first capture
+// group. If pattern has no capture group, whole match is used.
+// Every match is returned as version.
+//
+// For example, to extract `1.2.3` from `<a href="/dl/tool-1.2.3.zip">`,
+//
+//	&latest.RegexpScrap{Pattern: `tool-(\d+\.\d+\.\d+)\.zip`}
+type RegexpScrap struct {
+	// Pattern is regular expression which matches version.
+	// It MUST be set.
+	Pattern string
+
+	// MessagePattern and URLPattern are regular expressions to fill
+	// Meta.Message and Meta.URL. First match is used. These are optional.
+	MessagePattern string
+	URLPattern     string
+}
+
+func (s *RegexpScrap) Exec(r io.Reader) ([]string, *Meta, error) {
+	meta := &Meta{}
+
+	if len(s.Pattern) == 0 {
+		return []string{}, meta, validationErrorf("Pattern must be set")
+	}
+
+	re, err := regexp.Compile(s.Pattern)
+	if err != nil {
+		return []string{}, meta, validationErrorf("%s is invalid pattern: %s", s.Pattern, err)
+	}
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return []string{}, meta, err
+	}
+	body := string(b)
+
+	var verStrs []string
+	for _, m := range re.FindAllStringSubmatch(body, -1) {
+		verStr := regexpCapture(re, m)
+		if !containsString(verStrs, verStr) {
+			verStrs = append(verStrs, verStr)
+		}
+	}
+
+	if meta.Message, err = regexpFirst(s.MessagePattern, body); err != nil {
+		return verStrs, meta, err
+	}
+
+	if meta.URL, err = regexpFirst(s.URLPattern, body); err != nil {
+		return verStrs, meta, err
+	}
+
+	return verStrs, meta, nil
+}
+
+// regexpCapture returns capture group named `version`, first capture
+// group or whole match in this order.
+func regexpCapture(re *regexp.Regexp, match []string) string {
+	if i := re.SubexpIndex("version"); i > 0 {
+		return match[i]
+	}
+
+	if len(match) > 1 {
+		return match[1]
+	}
+
+	return match[0]
+}
+
+// regexpFirst returns capture of first match of pattern in body.
+// If pattern is empty or does not match, it returns empty string.
+func regexpFirst(pattern, body string) (string, error) {
+	if len(pattern) == 0 {
+		return "", nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", validationErrorf("%s is invalid pattern: %s", pattern, err)
+	}
+
+	m := re.FindStringSubmatch(body)
+	if m == nil {
+		return "", nil
+	}
+
+	return regexpCapture(re, m), nil
+}
@@ -0,0 +1,85 @@
+package latest
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestRegexpScrap_implement(t *testing.T) {
+	var _ HTMLScrap = &RegexpScrap{}
+}
+
+func TestRegexpScrapExec(t *testing.T) {
+	tests := []struct {
+		scrap         *RegexpScrap
+		expectVersion []string
+		expectMessage string
+		expectURL     string
+		expectErr     bool
+	}{
+		{
+			scrap:         &RegexpScrap{Pattern: `reduce-worker-(\d+\.\d+\.\d+)\.zip`},
+			expectVersion: []string{"1.2.3", "1.2.4"},
+		},
+		{
+			scrap:         &RegexpScrap{Pattern: `/dl/[a-z-]+-(?P<version>[0-9.]+(-beta\d+)?)\.zip`},
+			expectVersion: []string{"1.2.3", "1.2.4", "1.3.0-beta1"},
+		},
+		{
+			scrap: &RegexpScrap{
+				Pattern:        `<span class="version">[^<]+</span>`,
+				MessagePattern: `(?s)<p class="notice">\s*(.*?)\s*</p>`,
+				URLPattern:     `<link rel="alternate" href="([^"]+)">`,
+			},
+			expectVersion: []string{
+				`<span class="version">1.2.3</span>`,
+				`<span class="version">1.2.4</span>`,
+				`<span class="version">1.3.0-beta1</span>`,
+			},
+			expectMessage: "New version include <b>security update</b>",
+			expectURL:     "http://example.com/feed",
+		},
+		{
+			scrap:     &RegexpScrap{},
+			expectErr: true,
+		},
+		{
+			scrap:     &RegexpScrap{Pattern: `(`},
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		f, err := os.Open("test-fixtures/scrap.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		verStrs, meta, err := tt.scrap.Exec(f)
+		f.Close()
+
+		if tt.expectErr {
+			if err == nil {
+				t.Fatalf("#%d Exec() expects error", i)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("#%d Exec() expects error:%q to be nil", i, err.Error())
+		}
+
+		if !reflect.DeepEqual(verStrs, tt.expectVersion) {
+			t.Fatalf("#%d Exec() expects %#v to be %#v", i, verStrs, tt.expectVersion)
+		}
+
+		if meta.Message != tt.expectMessage {
+			t.Fatalf("#%d Exec() expects %q to be %q", i, meta.Message, tt.expectMessage)
+		}
+
+		if meta.URL != tt.expectURL {
+			t.Fatalf("#%d Exec() expects %q to be %q", i, meta.URL, tt.expectURL)
+		}
+	}
+}
@@ -0,0 +1,469 @@
+package latest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// SelectorScrap is HTMLScrap which extracts version information from
+// elements matched by CSS selector. It returns text content (or attribute
+// value when Attr is set) of every matched element as version.
+//
+// Supported selector is a subset of CSS: type (`div`), universal (`*`),
+// class (`.version`), ID (`#latest`), attribute (`[data-version]`,
+// `[rel="release"]`), descendant (`ul li`) and child (`ul > li`)
+// combinators, and selector list (`h1, h2`).
+type SelectorScrap struct {
+	// Selector is CSS selector which matches elements including version.
+	// It MUST be set.
+	Selector string
+
+	// Attr is attribute name to extract version from. If it's empty,
+	// text content of element is used.
+	Attr string
+
+	// MessageSelector and MessageAttr are used to fill Meta.Message.
+	// First matched element is used. These are optional.
+	MessageSelector string
+	MessageAttr     string
+
+	// URLSelector and URLAttr are used to fill Meta.URL.
+	// First matched element is used. These are optional.
+	URLSelector string
+	URLAttr     string
+}
+
+func (s *SelectorScrap) Exec(r io.Reader) ([]string, *Meta, error) {
+	meta := &Meta{}
+
+	if len(s.Selector) == 0 {
+		return []string{}, meta, validationErrorf("Selector must be set")
+	}
+
+	targets := make([]*selectorTarget, 0, 3)
+	for _, t := range []struct{ selector, attr string }{
+		{s.Selector, s.Attr},
+		{s.MessageSelector, s.MessageAttr},
+		{s.URLSelector, s.URLAttr},
+	} {
+		if len(t.selector) == 0 {
+			targets = append(targets, nil)
+			continue
+		}
+
+		sel, err := parseSelector(t.selector)
+		if err != nil {
+			return []string{}, meta, err
+		}
+		targets = append(targets, &selectorTarget{selector: sel, attr: t.attr})
+	}
+
+	if err := selectHTML(r, targets); err != nil {
+		return []string{}, meta, err
+	}
+
+	if t := targets[1]; t != nil && len(t.results) > 0 {
+		meta.Message = t.results[0]
+	}
+
+	if t := targets[2]; t != nil && len(t.results) > 0 {
+		meta.URL = t.results[0]
+	}
+
+	return targets[0].results, meta, nil
+}
+
+// selectorTarget holds selector and what to extract from matched elements.
+// Extracted values are stored in results in document order.
+type selectorTarget struct {
+	selector selectorGroup
+	attr     string
+	results  []string
+}
+
+// selectHTML parses HTML and fills results of each target. nil target
+// is skipped. Document tree is built by html.Parse, so end tags which
+// are omitted (e.g., `</li>` or `</p>`) are implied as browsers do.
+func selectHTML(r io.Reader, targets []*selectorTarget) error {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return err
+	}
+
+	selectNode(doc, nil, targets)
+	return nil
+}
+
+// selectNode matches n and its descendants against targets in document
+// order. ancestors are element ancestors of n.
+func selectNode(n *html.Node, ancestors []selectorNode, targets []*selectorTarget) {
+	if n.Type == html.ElementNode {
+		node := selectorNode{tag: n.Data, attrs: n.Attr}
+		for _, t := range targets {
+			if t == nil || !t.selector.match(node, ancestors) {
+				continue
+			}
+
+			if len(t.attr) != 0 {
+				if val, ok := node.attr(t.attr); ok {
+					t.results = append(t.results, strings.TrimSpace(val))
+				}
+				continue
+			}
+
+			t.results = append(t.results, textContent(n))
+		}
+		ancestors = append(ancestors, node)
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		// Slice is copied so that siblings don't share appended ancestors
+		selectNode(c, ancestors[:len(ancestors):len(ancestors)], targets)
+	}
+}
+
+// textContent returns text content of n with whitespace collapsed.
+func textContent(n *html.Node) string {
+	var texts []string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			texts = append(texts, n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return strings.Join(strings.Fields(strings.Join(texts, " ")), " ")
+}
+
+// selectorNode is an element which selector is matched against.
+type selectorNode struct {
+	tag   string
+	attrs []html.Attribute
+}
+
+func (n selectorNode) attr(key string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Namespace == "" && a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// selectorGroup is selector list separated by comma. It matches when
+// one of selectors matches.
+type selectorGroup []complexSelector
+
+func (g selectorGroup) match(n selectorNode, ancestors []selectorNode) bool {
+	for _, s := range g {
+		if s.match(n, ancestors) {
+			return true
+		}
+	}
+	return false
+}
+
+// complexSelector is compound selectors joined by combinators. Combinator
+// of compounds[i] is relation between compounds[i-1] and compounds[i].
+type complexSelector struct {
+	compounds   []compoundSelector
+	combinators []byte
+}
+
+func (s complexSelector) match(n selectorNode, ancestors []selectorNode) bool {
+	last := len(s.compounds) - 1
+	if !s.compounds[last].match(n) {
+		return false
+	}
+	return s.matchAncestors(last-1, s.combinators[last], ancestors)
+}
+
+// matchAncestors checks compounds[:i+1] against ancestors with combinator
+// comb which relates compounds[i] and the element already matched.
+func (s complexSelector) matchAncestors(i int, comb byte, ancestors []selectorNode) bool {
+	if i < 0 {
+		return true
+	}
+
+	for j := len(ancestors) - 1; j >= 0; j-- {
+		if s.compounds[i].match(ancestors[j]) && s.matchAncestors(i-1, s.combinators[i], ancestors[:j]) {
+			return true
+		}
+		// Child combinator allows only direct parent.
+		if comb == '>' {
+			break
+		}
+	}
+	return false
+}
+
+// compoundSelector is a sequence of simple selectors (e.g., `div.version`).
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []attrSelector
+}
+
+type attrSelector struct {
+	key      string
+	val      string
+	hasValue bool
+}
+
+func (s compoundSelector) match(n selectorNode) bool {
+	if s.tag != "" && s.tag != "*" && !strings.EqualFold(s.tag, n.tag) {
+		return false
+	}
+
+	if s.id != "" {
+		if id, _ := n.attr("id"); id != s.id {
+			return false
+		}
+	}
+
+	if len(s.classes) != 0 {
+		class, _ := n.attr("class")
+		fields := strings.Fields(class)
+		for _, c := range s.classes {
+			if !containsString(fields, c) {
+				return false
+			}
+		}
+	}
+
+	for _, a := range s.attrs {
+		val, ok := n.attr(a.key)
+		if !ok || (a.hasValue && val != a.val) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+func parseSelector(str string) (selectorGroup, error) {
+	outside, err := outsideBrackets(str)
+	if err != nil {
+		return nil, validationErrorf("invalid selector %q: %s", str, err)
+	}
+
+	var group selectorGroup
+	start := 0
+	for i := 0; i <= len(str); i++ {
+		if i < len(str) && !(outside[i] && str[i] == ',') {
+			continue
+		}
+
+		s, err := parseComplexSelector(strings.TrimSpace(str[start:i]))
+		if err != nil {
+			return nil, validationErrorf("invalid selector %q: %s", str, err)
+		}
+		group = append(group, s)
+		start = i + 1
+	}
+	return group, nil
+}
+
+// outsideBrackets reports whether each byte of str is outside attribute
+// selectors, so that ',', '>' or whitespace in (quoted) attribute value
+// is not taken as separator.
+func outsideBrackets(str string) ([]bool, error) {
+	outside := make([]bool, len(str))
+
+	var quote byte
+	inBracket := false
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case inBracket:
+			switch c {
+			case '"', '\'':
+				quote = c
+			case ']':
+				inBracket = false
+			}
+		case c == '[':
+			inBracket = true
+		default:
+			outside[i] = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("missing closing %c", quote)
+	}
+	if inBracket {
+		return nil, fmt.Errorf("missing ']'")
+	}
+	return outside, nil
+}
+
+// selectorFields splits complex selector into compound selectors and
+// '>' combinators.
+func selectorFields(str string) ([]string, error) {
+	outside, err := outsideBrackets(str)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			fields = append(fields, str[start:end])
+			start = -1
+		}
+	}
+
+	for i := 0; i < len(str); i++ {
+		switch {
+		case outside[i] && str[i] == '>':
+			flush(i)
+			fields = append(fields, ">")
+		case outside[i] && strings.IndexByte(" \t\n\r\f", str[i]) >= 0:
+			flush(i)
+		case start < 0:
+			start = i
+		}
+	}
+	flush(len(str))
+
+	return fields, nil
+}
+
+func parseComplexSelector(str string) (complexSelector, error) {
+	var s complexSelector
+	if len(str) == 0 {
+		return s, fmt.Errorf("empty selector")
+	}
+
+	fields, err := selectorFields(str)
+	if err != nil {
+		return s, err
+	}
+
+	comb := byte(' ')
+	for _, f := range fields {
+		if f == ">" {
+			if len(s.compounds) == 0 || comb == '>' {
+				return s, fmt.Errorf("unexpected '>'")
+			}
+			comb = '>'
+			continue
+		}
+
+		c, err := parseCompoundSelector(f)
+		if err != nil {
+			return s, err
+		}
+		s.compounds = append(s.compounds, c)
+		s.combinators = append(s.combinators, comb)
+		comb = ' '
+	}
+
+	if comb == '>' {
+		return s, fmt.Errorf("unexpected '>' at the end")
+	}
+
+	return s, nil
+}
+
+func parseCompoundSelector(str string) (compoundSelector, error) {
+	var s compoundSelector
+
+	// Type selector comes first.
+	n := strings.IndexAny(str, ".#[")
+	if n < 0 {
+		n = len(str)
+	}
+	s.tag, str = str[:n], str[n:]
+
+	for len(str) > 0 {
+		switch str[0] {
+		case '.', '#':
+			n := strings.IndexAny(str[1:], ".#[")
+			if n < 0 {
+				n = len(str) - 1
+			}
+			name := str[1 : n+1]
+			if len(name) == 0 {
+				return s, fmt.Errorf("empty name after %q", str[0])
+			}
+			if str[0] == '.' {
+				s.classes = append(s.classes, name)
+			} else {
+				s.id = name
+			}
+			str = str[n+1:]
+
+		case '[':
+			// Find ']' which is not in quoted value
+			end := 0
+			var quote byte
+			for i := 1; i < len(str) && end == 0; i++ {
+				switch c := str[i]; {
+				case quote != 0:
+					if c == quote {
+						quote = 0
+					}
+				case c == '"' || c == '\'':
+					quote = c
+				case c == ']':
+					end = i
+				}
+			}
+			if end == 0 {
+				return s, fmt.Errorf("missing ']'")
+			}
+			inside := str[1:end]
+			str = str[end+1:]
+
+			var a attrSelector
+			if eq := strings.Index(inside, "="); eq >= 0 {
+				a.key = strings.TrimSpace(inside[:eq])
+				a.val = unquoteAttr(strings.TrimSpace(inside[eq+1:]))
+				a.hasValue = true
+			} else {
+				a.key = strings.TrimSpace(inside)
+			}
+			if len(a.key) == 0 {
+				return s, fmt.Errorf("empty attribute name")
+			}
+			s.attrs = append(s.attrs, a)
+
+		default:
+			return s, fmt.Errorf("unexpected %q", str[0])
+		}
+	}
+
+	return s, nil
+}
+
+// unquoteAttr removes quotes around attribute value.
+func unquoteAttr(val string) string {
+	if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+		return val[1 : len(val)-1]
+	}
+	return val
+}
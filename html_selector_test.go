@@ -0,0 +1,173 @@
+package latest
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectorScrap_implement(t *testing.T) {
+	var _ HTMLScrap = &SelectorScrap{}
+}
+
+func TestSelectorScrapExec(t *testing.T) {
+	tests := []struct {
+		scrap         *SelectorScrap
+		expectVersion []string
+		expectMessage string
+		expectURL     string
+		expectErr     bool
+	}{
+		{
+			scrap:         &SelectorScrap{Selector: "#releases .version"},
+			expectVersion: []string{"1.2.3", "1.2.4", "1.3.0-beta1"},
+		},
+		{
+			scrap:         &SelectorScrap{Selector: "li.release.stable > span.version"},
+			expectVersion: []string{"1.2.3", "1.2.4"},
+		},
+		{
+			scrap:         &SelectorScrap{Selector: "body > .version, li.beta span"},
+			expectVersion: []string{"1.3.0-beta1", "9.9.9"},
+		},
+		{
+			scrap:         &SelectorScrap{Selector: "ul > span"},
+			expectVersion: nil,
+		},
+		{
+			scrap: &SelectorScrap{
+				Selector:        "li.stable a",
+				Attr:            "href",
+				MessageSelector: "p.notice",
+				URLSelector:     `link[rel="alternate"]`,
+				URLAttr:         "href",
+			},
+			expectVersion: []string{"/dl/reduce-worker-1.2.3.zip", "/dl/reduce-worker-1.2.4.zip"},
+			expectMessage: "New version include security update",
+			expectURL:     "http://example.com/feed",
+		},
+		{
+			// Whitespace and ',' in quoted attribute value
+			scrap:         &SelectorScrap{Selector: `a[title="Latest release"]`, Attr: "href"},
+			expectVersion: []string{"/dl/reduce-worker-1.2.4.zip"},
+		},
+		{
+			scrap:         &SelectorScrap{Selector: `li > a[data-v="a,b"][title='Latest release'], div.version`},
+			expectVersion: []string{"download", "9.9.9"},
+		},
+		{
+			scrap:         &SelectorScrap{Selector: `a[title="a > b, c"]`},
+			expectVersion: nil,
+		},
+		{
+			scrap:     &SelectorScrap{},
+			expectErr: true,
+		},
+		{
+			scrap:     &SelectorScrap{Selector: `a[title="Latest release]`},
+			expectErr: true,
+		},
+		{
+			scrap:     &SelectorScrap{Selector: "div[class"},
+			expectErr: true,
+		},
+		{
+			scrap:     &SelectorScrap{Selector: "ul >"},
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		f, err := os.Open("test-fixtures/scrap.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		verStrs, meta, err := tt.scrap.Exec(f)
+		f.Close()
+
+		if tt.expectErr {
+			if err == nil {
+				t.Fatalf("#%d Exec() expects error", i)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("#%d Exec() expects error:%q to be nil", i, err.Error())
+		}
+
+		if !reflect.DeepEqual(verStrs, tt.expectVersion) {
+			t.Fatalf("#%d Exec() expects %#v to be %#v", i, verStrs, tt.expectVersion)
+		}
+
+		if meta.Message != tt.expectMessage {
+			t.Fatalf("#%d Exec() expects %q to be %q", i, meta.Message, tt.expectMessage)
+		}
+
+		if meta.URL != tt.expectURL {
+			t.Fatalf("#%d Exec() expects %q to be %q", i, meta.URL, tt.expectURL)
+		}
+	}
+}
+
+func TestSelectorScrapExec_impliedEndTag(t *testing.T) {
+	tests := []struct {
+		html          string
+		selector      string
+		expectVersion []string
+	}{
+		{
+			html:          `<ul><li>1.0.0<li>1.1.0</ul>`,
+			selector:      "li",
+			expectVersion: []string{"1.0.0", "1.1.0"},
+		},
+		{
+			html:          `<p class="version">1.0.0<p class="version">1.1.0`,
+			selector:      "p.version",
+			expectVersion: []string{"1.0.0", "1.1.0"},
+		},
+		{
+			html:          `<table><tr><td>reduce-worker<td class="v">1.0.0<tr><td>great-worker<td class="v">0.1.1</table>`,
+			selector:      "tr > td.v",
+			expectVersion: []string{"1.0.0", "0.1.1"},
+		},
+		{
+			html:          `<select><option>1.0.0<option>1.1.0</select>`,
+			selector:      "select option",
+			expectVersion: []string{"1.0.0", "1.1.0"},
+		},
+	}
+
+	for i, tt := range tests {
+		s := &SelectorScrap{Selector: tt.selector}
+		verStrs, _, err := s.Exec(strings.NewReader(tt.html))
+		if err != nil {
+			t.Fatalf("#%d Exec() expects error:%q to be nil", i, err.Error())
+		}
+
+		if !reflect.DeepEqual(verStrs, tt.expectVersion) {
+			t.Fatalf("#%d Exec() expects %q to be %q", i, verStrs, tt.expectVersion)
+		}
+	}
+}
+
+func TestHTMLFetch_selector(t *testing.T) {
+	ts := fakeServer("test-fixtures/scrap.html")
+	defer ts.Close()
+
+	h := &HTML{
+		URL:   ts.URL,
+		Scrap: &SelectorScrap{Selector: "li.stable .version"},
+	}
+
+	fr, err := h.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() expects error:%q to be nil", err.Error())
+	}
+
+	if len(fr.Versions) != 2 {
+		t.Fatalf("Fetch() expects number of versions %d to be 2", len(fr.Versions))
+	}
+}
@@ -0,0 +1,116 @@
+package latest
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize is max size of response body included in StatusError.
+const maxErrorBodySize = 512
+
+// newHTTPClient creates HTTP client which is used by HTTP based sources.
+func newHTTPClient() *http.Client {
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		Dial: func(n, a string) (net.Conn, error) {
+			return net.DialTimeout(n, a, defaultDialTimeout)
+		},
+	}
+
+	return &http.Client{
+		Transport: t,
+	}
+}
+
+// httpGet sends GET request to rawURL with Accept header. Request is
+// retried by RetryPolicy p (or DefaultRetryPolicy if p is nil).
+// See doRequest about returned errors.
+func httpGet(rawURL, accept string, p *RetryPolicy) (*http.Response, error) {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return nil, validationErrorf("%s is invalid URL: %s", rawURL, err.Error())
+	}
+
+	if accept != "" {
+		req.Header.Add("Accept", accept)
+	}
+
+	var resp *http.Response
+	err = retryPolicyOf(p).do(func() error {
+		var err error
+		resp, err = doRequest(req)
+		return err
+	})
+
+	return resp, err
+}
+
+// doRequest sends request and returns response only when its status is
+// 200. Otherwise it returns NetworkError, RateLimitError or StatusError.
+// Caller must close response body.
+func doRequest(req *http.Request) (*http.Response, error) {
+	u := req.URL.String()
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return nil, &NetworkError{URL: u, Err: err}
+	}
+
+	if resp.StatusCode == 200 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	statusErr := &StatusError{
+		URL:        u,
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(b)),
+	}
+
+	if isRateLimited(resp) {
+		return nil, &RateLimitError{
+			URL:   u,
+			Reset: rateLimitReset(resp.Header),
+			Err:   statusErr,
+		}
+	}
+
+	return nil, statusErr
+}
+
+// isRateLimited checks response is rejected by rate limit.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusForbidden &&
+		(resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != "")
+}
+
+// rateLimitReset returns time when rate limit is reset from Retry-After
+// or X-RateLimit-Reset header. It returns zero time if it's unknown.
+func rateLimitReset(h http.Header) time.Time {
+	if v := h.Get("Retry-After"); v != "" {
+		if sec, err := strconv.Atoi(v); err == nil {
+			return timeNow().Add(time.Duration(sec) * time.Second)
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return t
+		}
+	}
+
+	if v := h.Get("X-RateLimit-Reset"); v != "" {
+		if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(unix, 0)
+		}
+	}
+
+	return time.Time{}
+}
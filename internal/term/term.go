@@ -0,0 +1,20 @@
+// Package term detects terminal and its width. It's shared by Policy of
+// go-latest and notice package.
+package term
+
+import (
+	"os"
+)
+
+// IsTerminal returns true when f is a terminal. Character device which
+// is not a terminal (e.g., /dev/null) is not.
+func IsTerminal(f *os.File) bool {
+	_, ok := winsize(f)
+	return ok
+}
+
+// Width returns width of terminal f. It returns 0 when it's unknown.
+func Width(f *os.File) int {
+	cols, _ := winsize(f)
+	return cols
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin
+
+package term
+
+import (
+	"os"
+)
+
+// winsize can not detect terminal width on this platform, so it only
+// tells whether f is a character device.
+func winsize(f *os.File) (int, bool) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, false
+	}
+	return 0, info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,23 @@
+//go:build linux || darwin
+
+package term
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize returns columns of terminal f by TIOCGWINSZ. It fails when f
+// is not a terminal.
+func winsize(f *os.File) (int, bool) {
+	var ws struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0, false
+	}
+	return int(ws.Col), true
+}
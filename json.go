@@ -2,13 +2,8 @@ package latest
 
 import (
 	"encoding/json"
-	"fmt"
-	"net"
-	"net/http"
 	"net/url"
 	"time"
-
-	"github.com/hashicorp/go-version"
 )
 
 var (
@@ -22,7 +17,18 @@ type JSON struct {
 
 	// Response is used to decode json as Struct and extract version information.
 	// See JSONResponse interface. By Default, it is used defaultJSONResponse.
+	// To extract information from arbitrary JSON without writing code,
+	// use JSONPathResponse.
 	Response JSONResponse
+
+	// Client is information about running client. If it's set, it's sent
+	// as query parameters so that server can tailor response. By default,
+	// nothing is sent.
+	Client *ClientInfo
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
 }
 
 // JSONResponse is used to decode json as Struct and extract information.
@@ -35,10 +41,57 @@ type JSONResponse interface {
 	MetaInfo() (*Meta, error)
 }
 
+// JSONAdvisoryResponse is optional interface of JSONResponse.
+// If JSONResponse implements it, advisories are reported to Check.
+type JSONAdvisoryResponse interface {
+	// AdvisoryInfo is called from Fetch to extract security advisories.
+	AdvisoryInfo() ([]*Advisory, error)
+}
+
+// JSONReleaseNoteResponse is optional interface of JSONResponse.
+// If JSONResponse implements it, JSON can be used as ChangelogSource.
+type JSONReleaseNoteResponse interface {
+	// ReleaseNoteInfo is called from FetchReleaseNotes to extract
+	// release notes.
+	ReleaseNoteInfo() ([]*ReleaseNote, error)
+}
+
+// JSONRetractionResponse is optional interface of JSONResponse.
+// If JSONResponse implements it, retracted (or yanked) and deprecated
+// versions are excluded from Current in Check.
+type JSONRetractionResponse interface {
+	// RetractionInfo is called from Fetch to extract retracted or
+	// deprecated versions.
+	RetractionInfo() ([]*Retraction, error)
+}
+
+// JSONRolloutResponse is optional interface of JSONResponse.
+// If JSONResponse implements it, staged rollout of releases is reported
+// to Check (see Checker.Rollout).
+type JSONRolloutResponse interface {
+	// RolloutInfo is called from Fetch to extract staged rollout.
+	// Rollout is matched with version by its Version.
+	RolloutInfo() ([]*Rollout, error)
+}
+
 type defaultJSONResponse struct {
-	Version string `json:"version"`
-	Message string `json:"message"`
-	URL     string `json:"url"`
+	Version    string      `json:"version"`
+	Message    string      `json:"message"`
+	URL        string      `json:"url"`
+	MinVersion string      `json:"min_version"`
+	EOL        string      `json:"eol"`
+	Advisories []*Advisory `json:"advisories"`
+
+	Retractions []*Retraction `json:"retractions"`
+
+	Rollout *Rollout `json:"rollout"`
+
+	Releases []struct {
+		Version string `json:"version"`
+		Notes   string `json:"notes"`
+		URL     string `json:"url"`
+		Date    string `json:"date"`
+	} `json:"releases"`
 }
 
 func (res *defaultJSONResponse) VersionInfo() ([]string, error) {
@@ -47,11 +100,48 @@ func (res *defaultJSONResponse) VersionInfo() ([]string, error) {
 
 func (res *defaultJSONResponse) MetaInfo() (*Meta, error) {
 	return &Meta{
-		Message: res.Message,
-		URL:     res.URL,
+		Message:    res.Message,
+		URL:        res.URL,
+		MinVersion: res.MinVersion,
+		EOL:        res.EOL,
 	}, nil
 }
 
+func (res *defaultJSONResponse) AdvisoryInfo() ([]*Advisory, error) {
+	return res.Advisories, nil
+}
+
+func (res *defaultJSONResponse) RetractionInfo() ([]*Retraction, error) {
+	return res.Retractions, nil
+}
+
+func (res *defaultJSONResponse) RolloutInfo() ([]*Rollout, error) {
+	if res.Rollout == nil {
+		return nil, nil
+	}
+
+	// Rollout is of the version in the response
+	ro := *res.Rollout
+	if ro.Version == "" {
+		ro.Version = res.Version
+	}
+	return []*Rollout{&ro}, nil
+}
+
+func (res *defaultJSONResponse) ReleaseNoteInfo() ([]*ReleaseNote, error) {
+	notes := make([]*ReleaseNote, 0, len(res.Releases))
+	for _, r := range res.Releases {
+		date, _ := parseDate(r.Date)
+		notes = append(notes, &ReleaseNote{
+			Version: r.Version,
+			Body:    r.Notes,
+			URL:     r.URL,
+			Date:    date,
+		})
+	}
+	return notes, nil
+}
+
 func (j *JSON) response() JSONResponse {
 	if j.Response == nil {
 		return &defaultJSONResponse{}
@@ -63,12 +153,12 @@ func (j *JSON) response() JSONResponse {
 func (j *JSON) Validate() error {
 
 	if len(j.URL) == 0 {
-		return fmt.Errorf("URL must be set")
+		return validationErrorf("URL must be set")
 	}
 
 	// Check URL can be parsed by net.URL
 	if _, err := url.Parse(j.URL); err != nil {
-		return fmt.Errorf("%s is invalid URL: %s", j.URL, err.Error())
+		return validationErrorf("%s is invalid URL: %s", j.URL, err.Error())
 	}
 
 	return nil
@@ -78,64 +168,125 @@ func (j *JSON) Fetch() (*FetchResponse, error) {
 
 	fr := newFetchResponse()
 
-	// URL is validated before call
-	u, _ := url.Parse(j.URL)
-
-	// Create a new request
-	req, err := http.NewRequest("GET", u.String(), nil)
+	result, err := j.decode()
 	if err != nil {
 		return fr, err
 	}
-	req.Header.Add("Accept", "application/json")
 
-	// Create client
-	t := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		Dial: func(n, a string) (net.Conn, error) {
-			return net.DialTimeout(n, a, defaultDialTimeout)
-		},
+	verStrs, err := result.VersionInfo()
+	if err != nil {
+		return fr, wrapParseError(j.URL, err)
+	}
+
+	if len(verStrs) == 0 {
+		return fr, noVersionsError("version info is not found on %s", j.URL)
 	}
 
-	client := &http.Client{
-		Transport: t,
+	for _, verStr := range verStrs {
+		fr.addRelease(verStr, verStr)
 	}
 
-	resp, err := client.Do(req)
+	fr.Meta, err = result.MetaInfo()
 	if err != nil {
-		return fr, err
+		return fr, wrapParseError(j.URL, err)
 	}
 
-	if resp.StatusCode != 200 {
-		return fr, fmt.Errorf("unknown status: %d", resp.StatusCode)
+	// Meta describes the version when there is only one
+	if len(fr.Releases) == 1 && fr.Meta != nil {
+		fr.Releases[0].URL = fr.Meta.URL
+		fr.Releases[0].Notes = fr.Meta.Message
 	}
 
-	result := j.response()
-	dec := json.NewDecoder(resp.Body)
-	if err := dec.Decode(&result); err != nil {
-		return fr, err
+	if rr, ok := result.(JSONReleaseNoteResponse); ok {
+		notes, err := rr.ReleaseNoteInfo()
+		if err != nil {
+			return fr, wrapParseError(j.URL, err)
+		}
+
+		for _, note := range notes {
+			r := findRelease(fr.Releases, HashicorpVersion{}, note.Version)
+			if r == nil {
+				continue
+			}
+			r.Date = note.Date
+			if note.URL != "" {
+				r.URL = note.URL
+			}
+			if note.Body != "" {
+				r.Notes = note.Body
+			}
+		}
 	}
 
-	verStrs, err := result.VersionInfo()
-	if err != nil {
-		return fr, err
+	if rr, ok := result.(JSONRetractionResponse); ok {
+		rs, err := rr.RetractionInfo()
+		if err != nil {
+			return fr, wrapParseError(j.URL, err)
+		}
+		fr.retract(rs)
 	}
 
-	if len(verStrs) == 0 {
-		return fr, fmt.Errorf("version info is not found on %s", j.URL)
+	if rr, ok := result.(JSONRolloutResponse); ok {
+		rollouts, err := rr.RolloutInfo()
+		if err != nil {
+			return fr, wrapParseError(j.URL, err)
+		}
+
+		for _, ro := range rollouts {
+			if r := findRelease(fr.Releases, HashicorpVersion{}, ro.Version); r != nil {
+				r.Rollout = ro
+			}
+		}
 	}
 
-	for _, verStr := range verStrs {
-		v, err := version.NewVersion(verStr)
+	if ar, ok := result.(JSONAdvisoryResponse); ok {
+		fr.Advisories, err = ar.AdvisoryInfo()
 		if err != nil {
-			fr.Malformeds = append(fr.Malformeds, verStr)
+			return fr, wrapParseError(j.URL, err)
 		}
-		fr.Versions = append(fr.Versions, v)
 	}
 
-	fr.Meta, err = result.MetaInfo()
+	return fr, nil
+}
+
+// FetchReleaseNotes implements ChangelogSource. JSONResponse must
+// implement JSONReleaseNoteResponse.
+func (j *JSON) FetchReleaseNotes() ([]*ReleaseNote, error) {
+	result, err := j.decode()
 	if err != nil {
-		return fr, err
+		return nil, err
 	}
 
-	return fr, nil
+	rr, ok := result.(JSONReleaseNoteResponse)
+	if !ok {
+		return nil, validationErrorf("JSON response doesn't include release notes")
+	}
+
+	notes, err := rr.ReleaseNoteInfo()
+	if err != nil {
+		return nil, wrapParseError(j.URL, err)
+	}
+	return notes, nil
+}
+
+// decode requests URL and decodes its response with JSONResponse.
+func (j *JSON) decode() (JSONResponse, error) {
+
+	// URL is validated before call
+	u, _ := url.Parse(j.URL)
+	j.Client.addQuery(u)
+
+	resp, err := httpGet(u.String(), "application/json", j.Retry)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := j.response()
+	dec := json.NewDecoder(resp.Body)
+	if err := dec.Decode(&result); err != nil {
+		return nil, &ParseError{Input: j.URL, Err: err}
+	}
+
+	return result, nil
 }
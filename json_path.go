@@ -0,0 +1,244 @@
+package latest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPathResponse is JSONResponse which extracts version information and
+// meta information from arbitrary JSON with path expressions. It is useful
+// to check third-party API without implementing your own JSONResponse.
+//
+// Path expression is a subset of JSONPath. It starts with optional `$` and
+// followed by `.key`, `['key']`, `[N]`, `[*]` or `.*`. For example,
+//
+//	$.releases[*].tag_name
+//	$.latest.notes
+type JSONPathResponse struct {
+	// VersionPath is path expression which points version string(s).
+	// It MUST be set. When it matches multiple values, all of them are
+	// used as version.
+	VersionPath string
+
+	// MessagePath and URLPath are path expressions which point Meta.Message
+	// and Meta.URL. When it matches multiple values, first one is used.
+	// These are optional.
+	MessagePath string
+	URLPath     string
+
+	// MinVersionPath and EOLPath are path expressions which point
+	// Meta.MinVersion and Meta.EOL. These are optional.
+	MinVersionPath string
+	EOLPath        string
+
+	data interface{}
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Decoded JSON is kept as it is
+// and evaluated with path expressions later.
+func (r *JSONPathResponse) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(&r.data)
+}
+
+func (r *JSONPathResponse) VersionInfo() ([]string, error) {
+	if len(r.VersionPath) == 0 {
+		return []string{}, validationErrorf("VersionPath must be set")
+	}
+
+	values, err := evalJSONPath(r.VersionPath, r.data)
+	if err != nil {
+		return []string{}, err
+	}
+
+	verStrs := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := jsonScalarString(v); ok {
+			verStrs = append(verStrs, s)
+		}
+	}
+
+	return verStrs, nil
+}
+
+func (r *JSONPathResponse) MetaInfo() (*Meta, error) {
+	meta := &Meta{}
+
+	var err error
+	if meta.Message, err = r.first(r.MessagePath); err != nil {
+		return meta, err
+	}
+
+	if meta.URL, err = r.first(r.URLPath); err != nil {
+		return meta, err
+	}
+
+	if meta.MinVersion, err = r.first(r.MinVersionPath); err != nil {
+		return meta, err
+	}
+
+	if meta.EOL, err = r.first(r.EOLPath); err != nil {
+		return meta, err
+	}
+
+	return meta, nil
+}
+
+// first returns first scalar value which expr points. If expr is empty
+// or it points nothing, it returns empty string.
+func (r *JSONPathResponse) first(expr string) (string, error) {
+	if len(expr) == 0 {
+		return "", nil
+	}
+
+	values, err := evalJSONPath(expr, r.data)
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range values {
+		if s, ok := jsonScalarString(v); ok {
+			return s, nil
+		}
+	}
+
+	return "", nil
+}
+
+// jsonScalarString converts decoded JSON scalar value into string.
+func jsonScalarString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// evalJSONPath evaluates path expression against decoded JSON value
+// and returns all matched values.
+func evalJSONPath(expr string, data interface{}) ([]interface{}, error) {
+	selectors, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	current := []interface{}{data}
+	for _, sel := range selectors {
+		var next []interface{}
+		for _, v := range current {
+			next = append(next, sel.apply(v)...)
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// jsonPathSelector is a single step of path expression. When wildcard is
+// true, it selects all children. Otherwise it selects key (object) or
+// index (array).
+type jsonPathSelector struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func (s jsonPathSelector) apply(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if s.wildcard {
+			res := make([]interface{}, 0, len(v))
+			for _, child := range v {
+				res = append(res, child)
+			}
+			return res
+		}
+		if s.isIndex {
+			return nil
+		}
+		if child, ok := v[s.key]; ok {
+			return []interface{}{child}
+		}
+
+	case []interface{}:
+		if s.wildcard {
+			return v
+		}
+		if !s.isIndex {
+			return nil
+		}
+		i := s.index
+		if i < 0 {
+			i += len(v)
+		}
+		if i >= 0 && i < len(v) {
+			return []interface{}{v[i]}
+		}
+	}
+
+	return nil
+}
+
+func parseJSONPath(expr string) ([]jsonPathSelector, error) {
+	p := strings.TrimSpace(expr)
+	p = strings.TrimPrefix(p, "$")
+
+	var selectors []jsonPathSelector
+	for len(p) > 0 {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			if strings.HasPrefix(p, "*") {
+				selectors = append(selectors, jsonPathSelector{wildcard: true})
+				p = p[1:]
+				continue
+			}
+			fallthrough
+
+		default:
+			n := strings.IndexAny(p, ".[")
+			if n < 0 {
+				n = len(p)
+			}
+			if n == 0 {
+				return nil, fmt.Errorf("invalid path expression %q: empty key", expr)
+			}
+			selectors = append(selectors, jsonPathSelector{key: p[:n]})
+			p = p[n:]
+
+		case '[':
+			end := strings.Index(p, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path expression %q: missing ']'", expr)
+			}
+			inside := strings.TrimSpace(p[1:end])
+			p = p[end+1:]
+
+			switch {
+			case inside == "*":
+				selectors = append(selectors, jsonPathSelector{wildcard: true})
+			case len(inside) >= 2 && (inside[0] == '\'' || inside[0] == '"') && inside[len(inside)-1] == inside[0]:
+				selectors = append(selectors, jsonPathSelector{key: inside[1 : len(inside)-1]})
+			default:
+				i, err := strconv.Atoi(inside)
+				if err != nil {
+					return nil, fmt.Errorf("invalid path expression %q: invalid index %q", expr, inside)
+				}
+				selectors = append(selectors, jsonPathSelector{index: i, isIndex: true})
+			}
+		}
+	}
+
+	return selectors, nil
+}
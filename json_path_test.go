@@ -0,0 +1,106 @@
+package latest
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestJSONPathResponse_implement(t *testing.T) {
+	var _ JSONResponse = &JSONPathResponse{}
+}
+
+func TestEvalJSONPath(t *testing.T) {
+	data := map[string]interface{}{
+		"version": "1.2.3",
+		"releases": []interface{}{
+			map[string]interface{}{"tag_name": "v1.0.0"},
+			map[string]interface{}{"tag_name": "v1.1.0"},
+		},
+		"latest": map[string]interface{}{
+			"release notes": "Bug fixes",
+		},
+	}
+
+	tests := []struct {
+		expr      string
+		expect    []interface{}
+		expectErr bool
+	}{
+		{expr: "$.version", expect: []interface{}{"1.2.3"}},
+		{expr: "version", expect: []interface{}{"1.2.3"}},
+		{expr: "$.releases[*].tag_name", expect: []interface{}{"v1.0.0", "v1.1.0"}},
+		{expr: "releases[*].tag_name", expect: []interface{}{"v1.0.0", "v1.1.0"}},
+		{expr: "$.releases[1].tag_name", expect: []interface{}{"v1.1.0"}},
+		{expr: "$.releases[-1].tag_name", expect: []interface{}{"v1.1.0"}},
+		{expr: "$.releases[5].tag_name", expect: nil},
+		{expr: "$.latest['release notes']", expect: []interface{}{"Bug fixes"}},
+		{expr: "$.latest.*", expect: []interface{}{"Bug fixes"}},
+		{expr: "$.unknown.key", expect: nil},
+		{expr: "$.releases[x]", expectErr: true},
+		{expr: "$.releases[0", expectErr: true},
+		{expr: "$..version", expectErr: true},
+	}
+
+	for i, tt := range tests {
+		values, err := evalJSONPath(tt.expr, data)
+		if tt.expectErr {
+			if err == nil {
+				t.Fatalf("#%d evalJSONPath(%q) expects error", i, tt.expr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("#%d evalJSONPath(%q) expects error:%q to be nil", i, tt.expr, err.Error())
+		}
+
+		if !reflect.DeepEqual(values, tt.expect) {
+			t.Fatalf("#%d evalJSONPath(%q) expects %#v to be %#v", i, tt.expr, values, tt.expect)
+		}
+	}
+}
+
+func TestJSONFetch_path(t *testing.T) {
+	ts := fakeServer("test-fixtures/path.json")
+	defer ts.Close()
+
+	j := &JSON{
+		URL: ts.URL,
+		Response: &JSONPathResponse{
+			VersionPath: "$.releases[*].tag_name",
+			MessagePath: "$.latest.notes",
+			URLPath:     "$.latest.links.html",
+		},
+	}
+
+	fr, err := j.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() expects error:%q to be nil", err.Error())
+	}
+
+	versions := fr.Versions
+	if len(versions) != 2 {
+		t.Fatalf("Fetch() expects number of versions %d to be 2", len(versions))
+	}
+
+	sort.Sort(version.Collection(versions))
+	current := versions[len(versions)-1].String()
+	if expect := "1.1.0"; current != expect {
+		t.Fatalf("Fetch() expects %s to be %s", current, expect)
+	}
+
+	if expect := []string{"nightly"}; !reflect.DeepEqual(fr.Malformeds, expect) {
+		t.Fatalf("Fetch() expects %v to be %v", fr.Malformeds, expect)
+	}
+
+	if expect := "Bug fixes and performance improvements"; fr.Meta.Message != expect {
+		t.Fatalf("Fetch() expects %q to be %q", fr.Meta.Message, expect)
+	}
+
+	if expect := "http://example.com/releases/v1.1.0"; fr.Meta.URL != expect {
+		t.Fatalf("Fetch() expects %q to be %q", fr.Meta.URL, expect)
+	}
+}
@@ -1,6 +1,7 @@
 package latest
 
 import (
+	"errors"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -112,3 +113,70 @@ func TestJSONFetch(t *testing.T) {
 
 	}
 }
+
+// brokenResponse returns error on VersionInfo like malformed payload.
+type brokenResponse struct{}
+
+func (r *brokenResponse) VersionInfo() ([]string, error) {
+	return nil, errors.New("version_info is not a string")
+}
+
+func (r *brokenResponse) MetaInfo() (*Meta, error) {
+	return &Meta{}, nil
+}
+
+func TestJSONFetch_parseError(t *testing.T) {
+	ts := fakeServer("test-fixtures/path.json")
+	defer ts.Close()
+
+	tests := []struct {
+		response JSONResponse
+	}{
+		{response: &JSONPathResponse{VersionPath: "$.releases[x]"}},
+		{response: &JSONPathResponse{VersionPath: "$.releases[*].tag_name", MessagePath: "$..notes"}},
+		{response: &brokenResponse{}},
+	}
+
+	for i, tt := range tests {
+		j := &JSON{URL: ts.URL, Response: tt.response}
+		_, err := j.Fetch()
+		if !errors.Is(err, ErrParse) {
+			t.Fatalf("#%d Fetch() expects error %v to be ErrParse", i, err)
+		}
+	}
+}
+
+func TestJSONFetch_advisories(t *testing.T) {
+	ts := fakeServer("test-fixtures/advisory.json")
+	defer ts.Close()
+
+	j := &JSON{URL: ts.URL}
+	fr, err := j.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() expects error:%q to be nil", err.Error())
+	}
+
+	if len(fr.Advisories) != 2 {
+		t.Fatalf("Fetch() expects number of advisories %d to be 2", len(fr.Advisories))
+	}
+
+	a := fr.Advisories[0]
+	if a.ID != "CVE-2015-0001" || a.Severity != SeverityCritical || a.Affected != ">= 1.0.0, < 1.2.3" || a.Fixed != "1.2.3" {
+		t.Fatalf("Fetch() expects advisory %#v to be decoded", a)
+	}
+}
+
+func TestJSONFetch_eol(t *testing.T) {
+	ts := fakeServer("test-fixtures/eol.json")
+	defer ts.Close()
+
+	j := &JSON{URL: ts.URL}
+	fr, err := j.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() expects error:%q to be nil", err.Error())
+	}
+
+	if fr.Meta.MinVersion != "1.5.0" || fr.Meta.EOL != "2016-01-01" {
+		t.Fatalf("Fetch() expects MinVersion, EOL (%q, %q) to be (%q, %q)", fr.Meta.MinVersion, fr.Meta.EOL, "1.5.0", "2016-01-01")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/hashicorp/go-version"
 )
@@ -52,20 +53,75 @@ type FetchResponse struct {
 	Versions   []*version.Version
 	Malformeds []string
 	Meta       *Meta
+
+	// Advisories is security advisories reported by source.
+	// Check compares them with target.
+	Advisories []*Advisory
+
+	// Filtered is tags or versions excluded by filter of source
+	// (e.g., TagFilterFunc). They are also included in Malformeds,
+	// but never parsed again by VersionScheme of Checker.
+	Filtered []string
+
+	// Releases is release records of Versions and Malformeds
+	// (except Filtered). It keeps original name and metadata which
+	// are not included in *version.Version.
+	Releases []*Release
 }
 
 // Meta is meta information from Fetch request.
 type Meta struct {
 	Message string
 	URL     string
+
+	// MinVersion is minimum supported version advertised by source.
+	// It's empty when source doesn't advertise it.
+	MinVersion string
+
+	// EOL is end-of-life date of versions less than MinVersion
+	// (`2006-01-02` or RFC3339 format). Until the date, such versions still
+	// work but are going to be unsupported. When it's empty, MinVersion
+	// takes effect immediately.
+	EOL string
 }
 
 // CheckResponse is a response for a Check request.
 type CheckResponse struct {
-	// Current is current latest version on source.
+	// Target is target version which is checked.
+	Target string
+
+	// Status is how the response is made. Fields below are not set
+	// when it's StatusSkipped (or StatusFailedSoft without cache).
+	Status Status
+
+	// SkipReason is why check is skipped by policy, e.g., `CI is set`.
+	SkipReason string
+
+	// Err is error tolerated by Checker.SoftFail when Status is
+	// StatusFailedSoft.
+	Err error `json:"-"`
+
+	// Current is current latest version on source. It's empty when
+	// all versions are held back by Checker.MinAge or Checker.Rollout,
+	// and then target is treated as latest.
 	Current string
 
+	// CurrentRelease is release record of Current. It's nil when
+	// source doesn't provide it.
+	CurrentRelease *Release
+
+	// CurrentAge is how long ago Current was published. It's zero when
+	// source doesn't provide publish date.
+	CurrentAge time.Duration
+
+	// RolloutPending is true when a version greater than Current (or
+	// any version when Current is empty) is released but not reported
+	// to this install yet by staged rollout.
+	RolloutPending bool
+
 	// Outdate is true when target version is less than Curernt on source.
+	// It's also true when target version is retracted or deprecated,
+	// because retracted or deprecated versions are never Current.
 	Outdated bool
 
 	// Latest is true when target version is equal to Current on source.
@@ -74,26 +130,174 @@ type CheckResponse struct {
 	// New is true when target version is greater than Current on source.
 	New bool
 
+	// Published is true when target version is included in versions on
+	// source. It's false for unreleased builds (e.g., `0.0.0-dev` or next
+	// version which is not released yet), even when Latest or New is true.
+	Published bool
+
+	// Retracted is true when target version is published but its release
+	// record is marked as retracted (or yanked) by source.
+	Retracted bool
+
+	// Deprecated is true when target version is published but its release
+	// record is marked as deprecated by source.
+	Deprecated bool
+
+	// TargetRelease is release record of target version. It's nil when
+	// target is not published or source doesn't provide it. Its Reason
+	// tells why target is retracted or deprecated.
+	TargetRelease *Release
+
 	// Malformed store versions or tags which can not be parsed as
 	// Semantic versioning (not compared with target).
 	Malformeds []string
 
 	// Meta is meta information from source.
 	Meta *Meta
+
+	// Vulnerable is true when target version is affected by one of
+	// advisories reported by source.
+	Vulnerable bool
+
+	// Advisories store advisories which affect target version.
+	Advisories []*Advisory
+
+	// EOL is true when target version is less than minimum supported
+	// version advertised by source. It may still work until EOLDate.
+	EOL bool
+
+	// EOLDate is end-of-life date advertised by source. It's zero when
+	// source doesn't advertise it.
+	EOLDate time.Time
+
+	// Unsupported is true when target version is EOL and EOLDate
+	// has passed (or it's not advertised). Tools should refuse to run.
+	Unsupported bool
+
+	// MalformedEOL is EOL advertised by source which can not be parsed.
+	// Then EOLDate is unknown, and Unsupported is never set.
+	MalformedEOL string
 }
 
 // Check fetches last version information from its source
 // and compares with target and return result (CheckResponse).
+// To use more options, use Checker.
 func Check(s Source, target string) (*CheckResponse, error) {
+	c := &Checker{Source: s}
+	return c.Check(target)
+}
 
-	if os.Getenv(EnvGoLatestDisable) != "" {
-		return &CheckResponse{}, nil
+// Checker is used to check target version with options.
+type Checker struct {
+	// Source is version information source. It MUST be set.
+	Source Source
+
+	// AdvisorySource is used to fetch security advisories which affect
+	// target version, in addition to advisories reported by Source.
+	// By default, it's not used.
+	AdvisorySource AdvisorySource
+
+	// Scheme is used to parse and compare versions. By default,
+	// HashicorpVersion (hashicorp/go-version) is used.
+	Scheme VersionScheme
+
+	// IgnorePrerelease excludes pre-release versions (judged by Scheme)
+	// from candidates of Current. By default, they are included.
+	IgnorePrerelease bool
+
+	// MinAge excludes versions published less than MinAge ago from
+	// candidates of Current (release cooldown), so that a release which
+	// is pulled soon after is never recommended. Versions whose publish
+	// date is unknown (Release.Date is zero) are not excluded. By default,
+	// it's zero and all versions are candidates.
+	MinAge time.Duration
+
+	// Rollout reports releases in staged rollout (see Rollout) only to
+	// installs selected by the policy. By default, it's nil and rollout
+	// is ignored, i.e., all releases are reported.
+	Rollout *RolloutPolicy
+
+	// Policy decides whether check is skipped in the running environment
+	// (e.g., in CI). By default, it's nil and check is skipped only when
+	// EnvGoLatestDisable is set.
+	Policy *Policy
+
+	// Cache stores response of each target. Response is loaded from
+	// Cache without accessing source while it's newer than CacheTTL.
+	// Errors on Cache are ignored. By default, nothing is cached.
+	Cache    Cache
+	CacheTTL time.Duration
+
+	// SoftFail tolerates errors caused by network (ErrNetwork, ErrStatus
+	// and ErrRateLimited). Check returns response with StatusFailedSoft
+	// (filled with cached one if any) instead of the error.
+	SoftFail bool
+}
+
+func (c *Checker) scheme() VersionScheme {
+	if c.Scheme == nil {
+		return HashicorpVersion{}
+	}
+
+	return c.Scheme
+}
+
+// Check fetches last version information from its source
+// and compares with target and return result (CheckResponse).
+func (c *Checker) Check(target string) (*CheckResponse, error) {
+
+	if reason := c.skipReason(); reason != "" {
+		return &CheckResponse{Target: target, Status: StatusSkipped, SkipReason: reason}, nil
 	}
 
-	// Convert target to *version.Version
-	targetV, err := version.NewVersion(target)
+	var cached *CheckResponse
+	if c.Cache != nil {
+		res, stored, ok := c.Cache.Load(target)
+		if ok {
+			// Copy not to modify response held by in-memory Cache
+			r := *res
+			cached = &r
+		}
+		if ok && timeNow().Sub(stored) < c.CacheTTL {
+			cached.Status = StatusCached
+			return cached, nil
+		}
+	}
+
+	res, err := c.check(target)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse %s, %s", target, err.Error())
+		if !c.SoftFail || !softError(err) {
+			return nil, err
+		}
+
+		if cached == nil {
+			cached = &CheckResponse{Target: target}
+		}
+		cached.Status = StatusFailedSoft
+		cached.Err = err
+		return cached, nil
+	}
+
+	if c.Cache != nil {
+		c.Cache.Store(target, res)
+	}
+
+	return res, nil
+}
+
+// check fetches versions from source and compares with target.
+func (c *Checker) check(target string) (*CheckResponse, error) {
+	scheme := c.scheme()
+
+	// Parse target by scheme
+	targetV, err := scheme.Parse(target)
+	if err != nil {
+		return nil, &ParseError{Input: target, Err: err}
+	}
+
+	s := c.Source
+	if s == nil {
+		return nil, validationErrorf("Source must be set")
 	}
 
 	// Validate source
@@ -107,36 +311,224 @@ func Check(s Source, target string) (*CheckResponse, error) {
 	}
 
 	// Source must has at leaset one version information
-	versions := fr.Versions
-	if len(fr.Versions) == 0 {
-		return nil, fmt.Errorf("no version to compare")
+	versions, malformeds := schemeVersions(scheme, fr)
+	published, targetRelease := checkPublished(versions, fr.Releases, scheme, targetV)
+
+	// Retracted or deprecated versions are never recommended as Current
+	versions = availableVersions(versions, fr.Releases, scheme)
+	if c.IgnorePrerelease {
+		versions = stableVersions(versions)
+	}
+	if len(versions) == 0 {
+		return nil, ErrNoVersions
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j].SchemeVersion) < 0
+	})
+
+	// Versions held back by cooldown or staged rollout are not Current.
+	// When all of them are held back, there is nothing to recommend and
+	// target is treated as latest.
+	rolled := versions
+	if c.Rollout != nil {
+		rolled = rolloutVersions(versions, fr.Releases, scheme, c.Rollout, timeNow())
+	}
+	selected := rolled
+	if c.MinAge > 0 {
+		selected, err = matureVersions(rolled, fr.Releases, scheme, timeNow().Add(-c.MinAge))
+		if err != nil {
+			return nil, err
+		}
 	}
-	sort.Sort(version.Collection(versions))
-	currentV := versions[len(versions)-1]
+
+	var current schemeCandidate
+	var currentV SchemeVersion
+	if len(selected) != 0 {
+		current = selected[len(selected)-1]
+		currentV = current.SchemeVersion
+	}
+	rolloutPending := heldBack(versions, rolled, currentV)
 
 	var outdated, latest, new bool
-	if targetV.LessThan(currentV) {
+	switch {
+	case targetRelease.withdrawn():
+		// If target is retracted or deprecated, target should be
+		// moved off even when it's greater than current
+		outdated = true
+	case currentV == nil:
+		// If all versions are held back, target is `latest`
+		latest = true
+	case targetV.Compare(currentV) < 0:
 		outdated = true
+	case targetV.Compare(currentV) == 0:
+		// If target = current, target is `latest`
+		latest = true
+	default:
+		// If target > current, target is `latest` and `new`
+		latest, new = true, true
 	}
 
-	// If target = current, target is `latest`
-	if targetV.Equal(currentV) {
-		latest = true
+	// Check target is affected by advisories
+	advisories := affecting(fr.Advisories, scheme, targetV)
+	if c.AdvisorySource != nil {
+		as, err := c.fetchAdvisories(targetV)
+		if err != nil {
+			return nil, err
+		}
+		advisories = append(advisories, affecting(as, scheme, targetV)...)
 	}
 
-	// If target > current, target is `latest` and `new`
-	if targetV.GreaterThan(currentV) {
-		latest, new = true, true
+	res := &CheckResponse{
+		Target:         target,
+		Status:         StatusChecked,
+		RolloutPending: rolloutPending,
+		Outdated:       outdated,
+		Latest:         latest,
+		New:            new,
+		Published:      published,
+		Retracted:      targetRelease != nil && targetRelease.Retracted,
+		Deprecated:     targetRelease != nil && targetRelease.Deprecated,
+		TargetRelease:  targetRelease,
+		Malformeds:     malformeds,
+		Meta:           fr.Meta,
+		Vulnerable:     len(advisories) > 0,
+		Advisories:     advisories,
+	}
+
+	if currentV != nil {
+		res.Current = currentV.String()
+		res.CurrentRelease = findRelease(fr.Releases, scheme, current.raw)
+	}
+
+	if r := res.CurrentRelease; r != nil {
+		date, err := r.PublishDate()
+		if err != nil {
+			return nil, err
+		}
+		if !date.IsZero() {
+			res.CurrentAge = timeNow().Sub(date)
+		}
+	}
+
+	checkEOL(res, scheme, targetV)
+
+	return res, nil
+}
+
+// skipReason returns why check is skipped by Policy. It returns empty
+// string when check should run.
+func (c *Checker) skipReason() string {
+	if c.Policy != nil {
+		return c.Policy.SkipReason()
+	}
+
+	if os.Getenv(EnvGoLatestDisable) != "" {
+		return fmt.Sprintf("%s is set", EnvGoLatestDisable)
+	}
+	return ""
+}
+
+// checkPublished checks target is included in versions and returns its
+// release record. When target matches multiple versions (e.g., `1.0`
+// and `1.0.0`), retracted or deprecated one is preferred.
+func checkPublished(versions []schemeCandidate, releases []*Release, scheme VersionScheme, targetV SchemeVersion) (published bool, release *Release) {
+	for _, v := range versions {
+		if v.Compare(targetV) != 0 {
+			continue
+		}
+
+		published = true
+		if r := findRelease(releases, scheme, v.raw); r != nil && !release.withdrawn() {
+			release = r
+		}
+	}
+
+	return published, release
+}
+
+// availableVersions returns versions whose release record is neither
+// retracted nor deprecated.
+func availableVersions(versions []schemeCandidate, releases []*Release, scheme VersionScheme) []schemeCandidate {
+	available := make([]schemeCandidate, 0, len(versions))
+	for _, v := range versions {
+		if findRelease(releases, scheme, v.raw).withdrawn() {
+			continue
+		}
+		available = append(available, v)
+	}
+	return available
+}
+
+// matureVersions returns sorted versions up to the greatest one published
+// before deadline. Publish date is resolved from the greatest version and
+// it stops at the first mature one, because resolving it may cost
+// a request (see Release.PublishDate). Versions whose publish date is
+// unknown are treated as mature.
+func matureVersions(versions []schemeCandidate, releases []*Release, scheme VersionScheme, deadline time.Time) ([]schemeCandidate, error) {
+	for i := len(versions) - 1; i >= 0; i-- {
+		r := findRelease(releases, scheme, versions[i].raw)
+		if r == nil {
+			return versions[:i+1], nil
+		}
+
+		date, err := r.PublishDate()
+		if err != nil {
+			return nil, err
+		}
+		if !date.After(deadline) {
+			return versions[:i+1], nil
+		}
+	}
+	return nil, nil
+}
+
+// timeNow returns current time. It's replaced in tests.
+var timeNow = time.Now
+
+// checkEOL sets EOL, EOLDate and Unsupported from Meta. Malformed
+// MinVersion advertised by source is ignored. Malformed EOL is reported
+// by MalformedEOL, and it doesn't make target Unsupported.
+func checkEOL(res *CheckResponse, scheme VersionScheme, targetV SchemeVersion) {
+	if res.Meta == nil {
+		return
+	}
+
+	if res.Meta.EOL != "" {
+		t, err := parseDate(res.Meta.EOL)
+		if err != nil {
+			res.MalformedEOL = res.Meta.EOL
+		}
+		res.EOLDate = t
+	}
+
+	if res.Meta.MinVersion == "" {
+		return
+	}
+
+	minV, err := scheme.Parse(res.Meta.MinVersion)
+	if err != nil || targetV.Compare(minV) >= 0 {
+		return
+	}
+
+	res.EOL = true
+	res.Unsupported = res.MalformedEOL == "" && !timeNow().Before(res.EOLDate)
+}
+
+// parseDate parses `2006-01-02` or RFC3339 format date.
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
+
+func (c *Checker) fetchAdvisories(targetV SchemeVersion) ([]*Advisory, error) {
+	if err := c.AdvisorySource.Validate(); err != nil {
+		return nil, err
 	}
 
-	return &CheckResponse{
-		Current:    currentV.String(),
-		Outdated:   outdated,
-		Latest:     latest,
-		New:        new,
-		Malformeds: fr.Malformeds,
-		Meta:       fr.Meta,
-	}, nil
+	return c.AdvisorySource.FetchAdvisories(targetV.String())
 }
 
 // newFetchResponse is constructor of FetchResponse. This is only for
@@ -9,6 +9,10 @@ import (
 	"github.com/tcnksm/go-latest"
 )
 
+// exitCodeUnknown is returned when it's unknown whether TAG is latest
+// because check is skipped or source is not reachable.
+const exitCodeUnknown = 2
+
 type CLI struct {
 	// out/err stream is the stdout and stderr
 	// to write message from CLI
@@ -17,7 +21,13 @@ type CLI struct {
 
 // Run executes CLI and return its exit code
 func (c *CLI) Run(args []string) int {
+	// Run subcommand
+	if len(args) > 1 && args[1] == "serve" {
+		return c.runServe(args[2:])
+	}
+
 	var githubTag latest.GithubTag
+	var jsonPath latest.JSONPathResponse
 
 	flags := flag.NewFlagSet(Name, flag.ExitOnError)
 	flags.Usage = func() { fmt.Fprintf(c.errStream, helpText) }
@@ -27,11 +37,34 @@ func (c *CLI) Run(args []string) int {
 		"repo", "", "Repository name")
 	flags.StringVar(&githubTag.Owner,
 		"owner", "", "Repository owner name")
+	flags.StringVar(&githubTag.Path,
+		"path", "", "Module path in monorepo")
+
+	flgModule := flags.String("module",
+		"", "Go module path to check on Go module proxy")
+	flgJSON := flags.String("json",
+		"", "URL of JSON which includes version information")
+	flags.StringVar(&jsonPath.VersionPath,
+		"version-path", "$.version", "Path expression to version(s) in JSON")
+	flags.StringVar(&jsonPath.MessagePath,
+		"message-path", "$.message", "Path expression to message in JSON")
+	flags.StringVar(&jsonPath.URLPath,
+		"url-path", "$.url", "Path expression to URL in JSON")
 
 	flgNew := flags.Bool("new",
 		false, "Check TAG(VERSION) is new and greater")
-	flgFixVerStrFunc := flags.String("fix",
-		"none", "Specify FixVersionStrFunc")
+	var flgFix fixFlag
+	flags.Var(&flgFix,
+		"fix", "Specify FixVersionStrFunc (can be repeated)")
+	var flgInclude, flgExclude filterFlag
+	flags.Var(&flgInclude,
+		"include", "Include only tags matched with filter (can be repeated)")
+	flags.Var(&flgExclude,
+		"exclude", "Exclude tags matched with filter (can be repeated)")
+	flgMinAge := flags.Duration("min-age",
+		0, "Ignore versions published less than duration ago")
+	flgScheme := flags.String("scheme",
+		"default", "Specify version scheme")
 	flgVersion := flags.Bool("version",
 		false, "Print version information")
 	flgHelp := flags.Bool("help",
@@ -68,27 +101,98 @@ func (c *CLI) Run(args []string) int {
 	}
 	target := parsedArgs[0]
 
+	// Options below are only for GitHub tags, so they can not be
+	// silently ignored with other sources
+	if *flgJSON != "" || *flgModule != "" {
+		var tagOnly string
+		flags.Visit(func(fl *flag.Flag) {
+			switch fl.Name {
+			case "path", "fix", "include", "exclude":
+				if tagOnly == "" {
+					tagOnly = fl.Name
+				}
+			}
+		})
+		if tagOnly != "" {
+			fmt.Fprintf(c.errStream, "-%s can not be used with -json or -module\n", tagOnly)
+			return 1
+		}
+	}
+
 	// Specify FixVersionStrFunc
 	// e.g., if version is v0.3.1 it should be 0.3.1 (SemVer format)
-	var f latest.FixVersionStrFunc
-	switch *flgFixVerStrFunc {
-	case "none":
-		f = nil
-	case "frontv":
-		f = latest.DeleteFrontV()
+	f, err := flgFix.fixFunc()
+	if err != nil {
+		fmt.Fprintf(c.errStream, "Invalid fix func: %s\n", err)
+		return 1
+	}
+	if f != nil {
 		target = f(target)
+	}
+
+	// Specify TagFilterFunc
+	filter, err := tagFilterFunc(flgInclude, flgExclude)
+	if err != nil {
+		fmt.Fprintf(c.errStream, "Invalid filter: %s\n", err)
+		return 1
+	}
+
+	// Specify VersionScheme
+	var scheme latest.VersionScheme
+	switch *flgScheme {
+	case "default":
+		scheme = nil
+	case "semver":
+		scheme = latest.SemVer{}
+	case "calver":
+		scheme = latest.CalVer{}
+	case "pep440":
+		scheme = latest.PEP440{}
+	case "debian":
+		scheme = latest.Debian{}
 	default:
-		fmt.Fprintf(c.errStream, "Invalid fix func: %s\n", *flgFixVerStrFunc)
+		fmt.Fprintf(c.errStream, "Invalid version scheme: %s\n", *flgScheme)
 		return 1
 	}
 
+	// Select source. By default, it uses GitHub tags.
+	var source latest.Source = &githubTag
+	if *flgJSON != "" {
+		source = &latest.JSON{
+			URL:      *flgJSON,
+			Response: &jsonPath,
+		}
+	}
+	if *flgModule != "" {
+		source = &latest.GoModule{
+			Module: *flgModule,
+		}
+	}
+
 	githubTag.FixVersionStrFunc = f
-	res, err := latest.Check(&githubTag, target)
+	githubTag.TagFilterFunc = filter
+	githubTag.CommitDate = *flgMinAge > 0
+	checker := &latest.Checker{
+		Source: source,
+		Scheme: scheme,
+		MinAge: *flgMinAge,
+	}
+	res, err := checker.Check(target)
 	if err != nil {
 		fmt.Fprintf(c.errStream, "Failed to check: %s\n", err.Error())
 		return 1
 	}
 
+	// Nothing is known about target when check is not done
+	switch res.Status {
+	case latest.StatusSkipped:
+		fmt.Fprintf(c.errStream, "Check is skipped: %s\n", res.SkipReason)
+		return exitCodeUnknown
+	case latest.StatusFailedSoft:
+		fmt.Fprintf(c.errStream, "Failed to check: %s\n", res.Err)
+		return exitCodeUnknown
+	}
+
 	// Default variables
 	exitCode := 0
 	output := fmt.Sprintf("%s is latest\n", target)
@@ -109,6 +213,17 @@ func (c *CLI) Run(args []string) int {
 	}
 
 	if *flgDebug {
+		if res.CurrentAge > 0 {
+			output += fmt.Sprintf("%s is published %s ago\n", res.Current, res.CurrentAge)
+		}
+		switch {
+		case res.Retracted:
+			output += fmt.Sprintf("%s is retracted: %s\n", target, res.TargetRelease.Reason)
+		case res.Deprecated:
+			output += fmt.Sprintf("%s is deprecated: %s\n", target, res.TargetRelease.Reason)
+		case !res.Published:
+			output += fmt.Sprintf("%s is not released\n", target)
+		}
 		fmt.Fprint(c.outStream, output)
 	}
 
@@ -116,6 +231,7 @@ func (c *CLI) Run(args []string) int {
 }
 
 const helpText = `Usage: latest [options] TAG
+       latest serve [options]
 
     latest command check TAG(VERSION) is latest. If is not latest,
     it returns non-zero value. It try to compare version by Semantic
@@ -127,18 +243,90 @@ Options:
 
     -repo=NAME     Set Github repository name.
 
+    -path=PATH     Use only tags of module PATH in monorepo (e.g., 'cli'
+                   for 'cli/v1.4.0' or 'tools/gen/v2' for
+                   'tools/gen/v2.0.1'). Use '.' for the root module.
+                   -path, -fix, -include and -exclude are only for
+                   GitHub tags and can not be used with -module or -json.
+
+    -module=PATH   Check version of Go module PATH on Go module proxy
+                   instead of GitHub tags. Retracted versions are
+                   never latest.
+
+    -json=URL      Check version from JSON response on URL instead of
+                   GitHub tags.
+
+    -version-path=PATH
+                   Path expression to version(s) in JSON response
+                   (default: '$.version'). e.g., '$.releases[*].tag_name'
+
+    -message-path=PATH
+                   Path expression to message in JSON response
+                   (default: '$.message').
+
+    -url-path=PATH Path expression to URL in JSON response
+                   (default: '$.url').
+
     -new           Check TAG(VERSION) is new. 'new' means TAG(VERSION)
                    is not exist and greater than others.
 
     -fix=none      Specify FixVersionStrFunc (Fix version string to SemVer)
+                   It can be repeated, and funcs are applied in order.
                    'none': does nothing (default)
-                   'front': deletes front 'v' charactor
+                   'frontv': deletes front 'v' charactor
+                   'prefix:STR': deletes prefix STR
+                   'suffix:STR': deletes suffix STR
+                   'project:NAME': deletes project name prefix
+                       (e.g., 'NAME-1.2.3' or 'NAME/v1.2.3')
+                   'regexp:PATTERN': extracts capture group of PATTERN
+                   'underscore': replaces '_' with '.'
+
+    -include=FILTER
+                   Include only tags matched with FILTER. It can be
+                   repeated, and tags matched with one of them are used.
+                   'GLOB' or 'glob:GLOB': glob pattern (e.g., 'v1.*')
+                   'regexp:PATTERN': regular expression
+                   'prefix:STR': tags start with STR
+                   'constraint:CONSTRAINT': version constraint
+                       (e.g., '>= 1.0, < 2.0')
+                   'stable': tags which are not pre-release
+
+    -exclude=FILTER
+                   Exclude tags matched with FILTER. It can be repeated.
+                   FILTER syntax is the same as -include.
+
+    -min-age=DURATION
+                   Ignore versions published less than DURATION ago
+                   (e.g., '72h'). Versions whose publish date is unknown
+                   are not ignored. With GitHub tags, it costs one more
+                   API request per tag from the newest one until an old
+                   enough one is found.
+
+    -scheme=default
+                   Specify version scheme to compare versions.
+                   'default': hashicorp/go-version (default)
+                   'semver': strict Semantic Versioning 2.0
+                   'calver': Calendar Versioning (e.g., 2024.10.1)
+                   'pep440': Python PEP 440
+                   'debian': Debian package version
 
     -help          Print this message and quit.
 
     -debug         Print verbose(debug) output.
 
+Exit status:
+
+    0    TAG(VERSION) is latest (or new with -new).
+    1    TAG(VERSION) is not latest (or not new), or check failed.
+    2    Check is skipped (e.g., GOLATEST_DISABLE is set).
+
 Example:
 
     $ latest -debug 0.2.0
+    $ latest -fix=project:mytool -fix=underscore mytool/v1_2_0
+    $ latest -module=github.com/tcnksm/ghr v0.2.0
+    $ latest -include='v1.*' -exclude=regexp:-rc 1.2.0
+    $ latest serve -manifest=manifest.json
+    $ latest -json=https://example.com/releases.json \
+        -version-path='$.releases[*].tag_name' 0.2.0
 `
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tcnksm/go-latest"
+)
+
+func TestRun_skipped(t *testing.T) {
+	if v, ok := os.LookupEnv(latest.EnvGoLatestDisable); ok {
+		defer os.Setenv(latest.EnvGoLatestDisable, v)
+	} else {
+		defer os.Unsetenv(latest.EnvGoLatestDisable)
+	}
+	os.Setenv(latest.EnvGoLatestDisable, "1")
+
+	outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+	cli := &CLI{outStream: outStream, errStream: errStream}
+
+	args := strings.Split("latest -debug -owner=tcnksm -repo=ghr 1.0.0", " ")
+	if status := cli.Run(args); status != exitCodeUnknown {
+		t.Fatalf("expects exit status %d to be %d", status, exitCodeUnknown)
+	}
+
+	expect := "Check is skipped: GOLATEST_DISABLE is set"
+	if !strings.Contains(errStream.String(), expect) {
+		t.Fatalf("expects %q to contain %q", errStream.String(), expect)
+	}
+
+	if outStream.Len() != 0 {
+		t.Fatalf("expects nothing to be printed as result: %q", outStream.String())
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/tcnksm/go-latest"
+)
+
+// filterFlag is flag.Value which collects -include or -exclude flags.
+// It can be specified multiple times.
+type filterFlag []string
+
+func (f *filterFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *filterFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+// tagFilterFunc returns TagFilterFunc which accepts tags matched with
+// one of -include (or all tags if it's not set) and not matched with
+// any of -exclude. It returns nil when no filter is set.
+func tagFilterFunc(includes, excludes filterFlag) (latest.TagFilterFunc, error) {
+	if len(includes) == 0 && len(excludes) == 0 {
+		return nil, nil
+	}
+
+	var fs []latest.TagFilterFunc
+
+	if len(includes) != 0 {
+		var ors []latest.TagFilterFunc
+		for _, spec := range includes {
+			f, err := parseFilter(spec)
+			if err != nil {
+				return nil, err
+			}
+			ors = append(ors, f)
+		}
+		fs = append(fs, latest.Or(ors...))
+	}
+
+	for _, spec := range excludes {
+		f, err := parseFilter(spec)
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, latest.Not(f))
+	}
+
+	return latest.And(fs...), nil
+}
+
+// parseFilter parses GLOB or NAME:ARG and returns TagFilterFunc.
+func parseFilter(spec string) (latest.TagFilterFunc, error) {
+	if spec == "stable" {
+		return latest.OnlyStable(), nil
+	}
+
+	if i := strings.Index(spec, ":"); i >= 0 {
+		name, arg := spec[:i], spec[i+1:]
+		switch name {
+		case "glob":
+			return latest.MatchGlob(arg), nil
+		case "prefix":
+			return latest.HasPrefix(arg), nil
+		case "regexp":
+			if _, err := regexp.Compile(arg); err != nil {
+				return nil, fmt.Errorf("invalid regexp %q: %s", arg, err)
+			}
+			return latest.MatchRegexp(arg), nil
+		case "constraint":
+			if _, err := version.NewConstraint(arg); err != nil {
+				return nil, fmt.Errorf("invalid constraint %q: %s", arg, err)
+			}
+			return latest.MatchConstraint(arg), nil
+		}
+	}
+
+	return latest.MatchGlob(spec), nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tcnksm/go-latest"
+)
+
+// fixFlag is flag.Value which collects -fix flags. It can be specified
+// multiple times and functions are chained in the given order.
+type fixFlag []string
+
+func (f *fixFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fixFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+// fixFunc returns FixVersionStrFunc which chains all -fix flags.
+// It returns nil when nothing to fix.
+func (f *fixFlag) fixFunc() (latest.FixVersionStrFunc, error) {
+	var fs []latest.FixVersionStrFunc
+	for _, spec := range *f {
+		fn, err := parseFix(spec)
+		if err != nil {
+			return nil, err
+		}
+		if fn != nil {
+			fs = append(fs, fn)
+		}
+	}
+
+	if len(fs) == 0 {
+		return nil, nil
+	}
+
+	return latest.Chain(fs...), nil
+}
+
+// parseFix parses NAME or NAME:ARG and returns FixVersionStrFunc.
+func parseFix(spec string) (latest.FixVersionStrFunc, error) {
+	name, arg := spec, ""
+	if i := strings.Index(spec, ":"); i >= 0 {
+		name, arg = spec[:i], spec[i+1:]
+	}
+
+	needArg := func() error {
+		if arg == "" {
+			return fmt.Errorf("fix func %s needs argument (e.g., %s:ARG)", name, name)
+		}
+		return nil
+	}
+
+	switch name {
+	case "none":
+		return nil, nil
+	case "frontv", "front":
+		return latest.DeleteFrontV(), nil
+	case "underscore":
+		return latest.UnderscoreToDot(), nil
+	case "prefix":
+		if err := needArg(); err != nil {
+			return nil, err
+		}
+		return latest.DeletePrefix(arg), nil
+	case "suffix":
+		if err := needArg(); err != nil {
+			return nil, err
+		}
+		return latest.DeleteSuffix(arg), nil
+	case "project":
+		if err := needArg(); err != nil {
+			return nil, err
+		}
+		return latest.DeleteProjectPrefix(arg), nil
+	case "regexp":
+		if err := needArg(); err != nil {
+			return nil, err
+		}
+		if _, err := regexp.Compile(arg); err != nil {
+			return nil, fmt.Errorf("invalid regexp %q: %s", arg, err)
+		}
+		return latest.CaptureRegexp(arg), nil
+	}
+
+	return nil, fmt.Errorf("invalid fix func: %s", spec)
+}
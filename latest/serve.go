@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tcnksm/go-latest/server"
+)
+
+// runServe runs `serve` subcommand which hosts go-latest endpoints
+// from manifest file. It blocks until server is stopped.
+func (c *CLI) runServe(args []string) int {
+	flags := flag.NewFlagSet(Name+" serve", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprintf(c.errStream, serveHelpText) }
+	flags.SetOutput(c.errStream)
+
+	flgManifest := flags.String("manifest",
+		"", "Path to manifest file")
+	flgAddr := flags.String("addr",
+		":8080", "Address to listen on")
+	flgMaxAge := flags.Duration("max-age",
+		server.DefaultMaxAge, "Cache-Control max-age")
+
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		fmt.Fprint(c.errStream, "Failed to parse flag\n")
+		return 1
+	}
+
+	if *flgManifest == "" {
+		fmt.Fprintf(c.errStream, "-manifest must be set\n")
+		return 1
+	}
+
+	m, err := server.LoadManifest(*flgManifest)
+	if err != nil {
+		fmt.Fprintf(c.errStream, "Failed to load manifest: %s\n", err.Error())
+		return 1
+	}
+
+	h := server.New(m)
+	h.MaxAge = *flgMaxAge
+
+	s := &http.Server{
+		Addr:         *flgAddr,
+		Handler:      h,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(c.errStream, "Serving %d product(s) on %s\n", len(m.Products), *flgAddr)
+	if err := s.ListenAndServe(); err != nil {
+		fmt.Fprintf(c.errStream, "Failed to serve: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+const serveHelpText = `Usage: latest serve [options]
+
+    serve hosts go-latest endpoints (JSON and HTML meta tag) for
+    products described in manifest file.
+
+Options:
+
+    -manifest=FILE  Path to manifest JSON file (required).
+
+    -addr=ADDR      Address to listen on (default: ':8080').
+
+    -max-age=DUR    Cache-Control max-age of responses (default: '5m').
+
+Example:
+
+    $ latest serve -manifest=manifest.json -addr=:8080
+`
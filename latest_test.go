@@ -1 +1,302 @@
 package latest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// fakeSource is Source which returns fixed FetchResponse.
+type fakeSource struct {
+	versions   []string
+	malformeds []string
+	filtered   []string
+	releases   []*Release
+	advisories []*Advisory
+	meta       *Meta
+
+	// err is returned by Fetch, and fetched counts Fetch calls
+	err     error
+	fetched int
+}
+
+func (s *fakeSource) Validate() error {
+	return nil
+}
+
+func (s *fakeSource) Fetch() (*FetchResponse, error) {
+	s.fetched++
+	fr := newFetchResponse()
+	if s.err != nil {
+		return fr, s.err
+	}
+	for _, verStr := range s.versions {
+		fr.Versions = append(fr.Versions, version.Must(version.NewVersion(verStr)))
+	}
+	fr.Malformeds = s.malformeds
+	fr.Filtered = s.filtered
+	fr.Releases = s.releases
+	fr.Advisories = s.advisories
+	if s.meta != nil {
+		fr.Meta = s.meta
+	}
+	return fr, nil
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		target         string
+		expectOutdated bool
+		expectLatest   bool
+		expectNew      bool
+	}{
+		{target: "1.1.0", expectOutdated: true},
+		{target: "1.2.3", expectLatest: true},
+		{target: "1.3.0", expectLatest: true, expectNew: true},
+	}
+
+	s := &fakeSource{versions: []string{"1.0.0", "1.2.3", "1.1.0"}}
+	for i, tt := range tests {
+		res, err := Check(s, tt.target)
+		if err != nil {
+			t.Fatalf("#%d Check() expects error:%q to be nil", i, err.Error())
+		}
+
+		if res.Current != "1.2.3" {
+			t.Fatalf("#%d Check() expects %s to be %s", i, res.Current, "1.2.3")
+		}
+
+		if res.Outdated != tt.expectOutdated || res.Latest != tt.expectLatest || res.New != tt.expectNew {
+			t.Fatalf("#%d Check() expects outdated, latest, new (%t, %t, %t) to be (%t, %t, %t)", i,
+				res.Outdated, res.Latest, res.New, tt.expectOutdated, tt.expectLatest, tt.expectNew)
+		}
+	}
+}
+
+func TestCheck_advisories(t *testing.T) {
+	critical := &Advisory{ID: "CVE-2015-0001", Affected: ">= 1.0.0, < 1.2.3", Severity: SeverityCritical}
+	low := &Advisory{ID: "CVE-2014-0002", Affected: "< 1.0.0", Severity: SeverityLow}
+	broken := &Advisory{ID: "BROKEN", Affected: "not a range"}
+
+	s := &fakeSource{
+		versions:   []string{"1.2.3"},
+		advisories: []*Advisory{critical, low, broken},
+	}
+
+	tests := []struct {
+		target           string
+		expectVulnerable bool
+		expectAdvisories []*Advisory
+	}{
+		{target: "0.9.0", expectVulnerable: true, expectAdvisories: []*Advisory{low}},
+		{target: "1.1.0", expectVulnerable: true, expectAdvisories: []*Advisory{critical}},
+		{target: "1.2.3", expectVulnerable: false},
+	}
+
+	for i, tt := range tests {
+		res, err := Check(s, tt.target)
+		if err != nil {
+			t.Fatalf("#%d Check() expects error:%q to be nil", i, err.Error())
+		}
+
+		if res.Vulnerable != tt.expectVulnerable {
+			t.Fatalf("#%d Check() expects %t to be %t", i, res.Vulnerable, tt.expectVulnerable)
+		}
+
+		if !reflect.DeepEqual(res.Advisories, tt.expectAdvisories) {
+			t.Fatalf("#%d Check() expects %v to be %v", i, res.Advisories, tt.expectAdvisories)
+		}
+	}
+}
+
+func TestCheck_eol(t *testing.T) {
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time {
+		return time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		target            string
+		meta              *Meta
+		expectEOL         bool
+		expectUnsupported bool
+		expectEOLDate     time.Time
+		expectMalformed   string
+	}{
+		{
+			target: "0.9.0",
+			meta:   &Meta{},
+		},
+		{
+			target:            "0.9.0",
+			meta:              &Meta{MinVersion: "1.0.0"},
+			expectEOL:         true,
+			expectUnsupported: true,
+		},
+		{
+			target: "1.0.0",
+			meta:   &Meta{MinVersion: "1.0.0"},
+		},
+		{
+			target:        "0.9.0",
+			meta:          &Meta{MinVersion: "1.0.0", EOL: "2015-07-01"},
+			expectEOL:     true,
+			expectEOLDate: time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			target:            "0.9.0",
+			meta:              &Meta{MinVersion: "1.0.0", EOL: "2015-05-01T00:00:00Z"},
+			expectEOL:         true,
+			expectUnsupported: true,
+			expectEOLDate:     time.Date(2015, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			target:          "0.9.0",
+			meta:            &Meta{MinVersion: "invalid", EOL: "invalid"},
+			expectMalformed: "invalid",
+		},
+		{
+			// Typo in EOL doesn't make target unsupported
+			target:          "0.9.0",
+			meta:            &Meta{MinVersion: "1.0.0", EOL: "2015-13-01"},
+			expectEOL:       true,
+			expectMalformed: "2015-13-01",
+		},
+	}
+
+	for i, tt := range tests {
+		s := &fakeSource{versions: []string{"1.2.3"}, meta: tt.meta}
+		res, err := Check(s, tt.target)
+		if err != nil {
+			t.Fatalf("#%d Check() expects error:%q to be nil", i, err.Error())
+		}
+
+		if res.EOL != tt.expectEOL || res.Unsupported != tt.expectUnsupported {
+			t.Fatalf("#%d Check() expects EOL, Unsupported (%t, %t) to be (%t, %t)", i,
+				res.EOL, res.Unsupported, tt.expectEOL, tt.expectUnsupported)
+		}
+
+		if !res.EOLDate.Equal(tt.expectEOLDate) {
+			t.Fatalf("#%d Check() expects %s to be %s", i, res.EOLDate, tt.expectEOLDate)
+		}
+
+		if res.MalformedEOL != tt.expectMalformed {
+			t.Fatalf("#%d Check() expects %q to be %q", i, res.MalformedEOL, tt.expectMalformed)
+		}
+	}
+}
+
+func TestCheck_published(t *testing.T) {
+	s := &fakeSource{
+		versions: []string{"1.0.0", "1.1.0", "1.2.0-rc1"},
+		releases: []*Release{
+			{Name: "v1.0.0", Version: "1.0.0"},
+			{Name: "v1.1.0", Version: "1.1.0", Retracted: true},
+			{Name: "v1.2.0-rc1", Version: "1.2.0-rc1"},
+		},
+	}
+
+	tests := []struct {
+		target           string
+		ignorePrerelease bool
+		expectPublished  bool
+		expectRetracted  bool
+		expectLatest     bool
+	}{
+		{target: "1.0.0", expectPublished: true},
+		{target: "v1.0", expectPublished: true},
+		{target: "1.1.0", expectPublished: true, expectRetracted: true},
+		{target: "1.2.0-rc1", ignorePrerelease: true, expectPublished: true, expectLatest: true},
+		{target: "0.0.0-dev"},
+		{target: "9.9.9", expectLatest: true},
+	}
+
+	for i, tt := range tests {
+		c := &Checker{Source: s, IgnorePrerelease: tt.ignorePrerelease}
+		res, err := c.Check(tt.target)
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Published != tt.expectPublished || res.Retracted != tt.expectRetracted || res.Latest != tt.expectLatest {
+			t.Fatalf("#%d expects published, retracted, latest (%t, %t, %t) to be (%t, %t, %t)", i,
+				res.Published, res.Retracted, res.Latest, tt.expectPublished, tt.expectRetracted, tt.expectLatest)
+		}
+	}
+}
+
+func TestCheck_minAge(t *testing.T) {
+	now := time.Date(2015, 6, 10, 0, 0, 0, 0, time.UTC)
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return now }
+
+	s := &fakeSource{
+		versions: []string{"1.0.0", "1.1.0", "1.2.0"},
+		releases: []*Release{
+			{Version: "1.0.0", Date: now.AddDate(0, 0, -30)},
+			{Version: "1.1.0", Date: now.AddDate(0, 0, -7)},
+			{Version: "1.2.0", Date: now.Add(-1 * time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		minAge        time.Duration
+		expectCurrent string
+		expectAge     time.Duration
+	}{
+		{minAge: 0, expectCurrent: "1.2.0", expectAge: time.Hour},
+		{minAge: 24 * time.Hour, expectCurrent: "1.1.0", expectAge: 7 * 24 * time.Hour},
+		{minAge: 14 * 24 * time.Hour, expectCurrent: "1.0.0", expectAge: 30 * 24 * time.Hour},
+
+		// Nothing is mature, so target is latest
+		{minAge: 365 * 24 * time.Hour, expectCurrent: ""},
+	}
+
+	for i, tt := range tests {
+		c := &Checker{Source: s, MinAge: tt.minAge}
+		res, err := c.Check("1.0.0")
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Current != tt.expectCurrent || res.CurrentAge != tt.expectAge {
+			t.Fatalf("#%d expects %q (%s) to be %q (%s)", i, res.Current, res.CurrentAge, tt.expectCurrent, tt.expectAge)
+		}
+
+		if res.Outdated != (tt.expectCurrent != "" && tt.expectCurrent != "1.0.0") || res.RolloutPending {
+			t.Fatalf("#%d expects outdated %t to follow current %q", i, res.Outdated, res.Current)
+		}
+	}
+
+	// Version whose publish date is unknown is not excluded
+	s.versions = append(s.versions, "1.3.0")
+	c := &Checker{Source: s, MinAge: 24 * time.Hour}
+	res, err := c.Check("1.0.0")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+	if res.Current != "1.3.0" || res.CurrentAge != 0 {
+		t.Fatalf("expects %q (%s) to be 1.3.0 (0s)", res.Current, res.CurrentAge)
+	}
+}
+
+func TestCheck_minAgeSingleRelease(t *testing.T) {
+	ts := fakeServer("test-fixtures/releases.json")
+	defer ts.Close()
+
+	// 1.5.0 is released on 2015-06-01
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	c := &Checker{Source: &JSON{URL: ts.URL}, MinAge: 72 * time.Hour}
+	res, err := c.Check("1.4.0")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	if !res.Latest || res.Outdated || res.Current != "" {
+		t.Fatalf("expects 1.4.0 to be latest while 1.5.0 is young: %#v", res)
+	}
+}
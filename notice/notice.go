@@ -0,0 +1,270 @@
+/*
+Package notice renders "new version available" notice of
+latest.CheckResponse for terminal in a consistent box,
+
+	╭────────────────────────────────────────────╮
+	│                                            │
+	│   Update available 0.1.0 → 0.2.0           │
+	│   New version include security update      │
+	│   https://github.com/tcnksm/ghr/releases   │
+	│   Run brew upgrade ghr to update           │
+	│                                            │
+	╰────────────────────────────────────────────╯
+
+Color is used by severity (e.g., red for vulnerable or unsupported
+version) only when output is a terminal and NO_COLOR is not set. Box
+width follows terminal width. Content can be customized with
+text/template.
+
+	res, _ := latest.Check(githubTag, version)
+	r := &notice.Renderer{Name: "ghr", InstallHint: "brew upgrade ghr"}
+	r.Render(os.Stderr, res)
+*/
+package notice
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/tcnksm/go-latest"
+	"github.com/tcnksm/go-latest/internal/term"
+)
+
+// Severity is how urgent the notice is.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// ColorMode decides whether notice is colored.
+type ColorMode int
+
+const (
+	// ColorAuto uses color when output is a terminal, NO_COLOR is not
+	// set and TERM is not `dumb`.
+	ColorAuto ColorMode = iota
+	ColorAlways
+	ColorNever
+)
+
+// DefaultTemplate is template of notice content used by default.
+// See Data about available fields, and Renderer.Template about
+// available functions.
+const DefaultTemplate = `{{.Title}} {{dim .Target}} → {{color .Latest}}
+{{- with .Reason}}
+{{.}}{{end}}
+{{- with .Message}}
+{{.}}{{end}}
+{{- with .URL}}
+{{cyan .}}{{end}}
+{{- with .InstallHint}}
+Run {{bold .}} to update{{end}}`
+
+var defaultTemplate = template.Must(newTemplate("notice").Parse(DefaultTemplate))
+
+// Renderer renders notice of CheckResponse.
+type Renderer struct {
+	// Name is name of the tool. It's used in title (e.g., `ghr 0.1.0
+	// is no longer supported`). It's optional.
+	Name string
+
+	// InstallHint is how to update the tool, e.g., `brew upgrade ghr`.
+	// It's optional.
+	InstallHint string
+
+	// Template renders content of the box with Data. It should be made
+	// by New to use functions below. By default, DefaultTemplate is used.
+	//
+	//	bold, dim, red, yellow, green, cyan  decorate text
+	//	color                                colors text by Severity
+	Template *template.Template
+
+	// Width is max width of the box. By default, terminal width (or
+	// COLUMNS) is used, and 80 when it's unknown.
+	Width int
+
+	// Color decides whether notice is colored. By default, ColorAuto.
+	Color ColorMode
+}
+
+// Data is passed to template.
+type Data struct {
+	// Name and InstallHint are the same as Renderer.
+	Name        string
+	InstallHint string
+
+	// Title is headline of the notice, e.g., `Update available`.
+	Title string
+
+	// Target is running version and Latest is Current on source.
+	Target string
+	Latest string
+
+	// Reason is why Target is retracted or deprecated.
+	Reason string
+
+	// Message and URL are from Meta (URL falls back to URL of
+	// CurrentRelease).
+	Message string
+	URL     string
+
+	Severity Severity
+
+	// Response is the original response.
+	Response *latest.CheckResponse
+}
+
+// New returns new template which has functions for notice.
+func New(name string) *template.Template {
+	return newTemplate(name)
+}
+
+// newTemplate returns template with placeholder functions. They are
+// replaced with actual ones on Render.
+func newTemplate(name string) *template.Template {
+	return template.New(name).Funcs((&painter{}).funcs(SeverityInfo))
+}
+
+// NeedsNotice returns true when res should be told to user, i.e., it's
+// checked (or cached) and target is outdated, vulnerable, EOL, retracted
+// or deprecated.
+func NeedsNotice(res *latest.CheckResponse) bool {
+	if res == nil || res.Current == "" {
+		return false
+	}
+
+	if res.Status == latest.StatusSkipped {
+		return false
+	}
+
+	return res.Outdated || res.Vulnerable || res.EOL || res.Retracted || res.Deprecated
+}
+
+// SeverityOf returns severity of res.
+func SeverityOf(res *latest.CheckResponse) Severity {
+	switch {
+	case res.Unsupported || res.Retracted:
+		return SeverityCritical
+	case res.Vulnerable:
+		for _, a := range res.Advisories {
+			if a.Severity == latest.SeverityHigh || a.Severity == latest.SeverityCritical {
+				return SeverityCritical
+			}
+		}
+		return SeverityWarning
+	case res.EOL || res.Deprecated:
+		return SeverityWarning
+	}
+	return SeverityInfo
+}
+
+// NewData returns Data of res for template.
+func (r *Renderer) NewData(res *latest.CheckResponse) *Data {
+	d := &Data{
+		Name:        r.Name,
+		InstallHint: r.InstallHint,
+		Target:      res.Target,
+		Latest:      res.Current,
+		Severity:    SeverityOf(res),
+		Response:    res,
+	}
+
+	if res.Meta != nil {
+		d.Message = res.Meta.Message
+		d.URL = res.Meta.URL
+	}
+	if d.URL == "" && res.CurrentRelease != nil {
+		d.URL = res.CurrentRelease.URL
+	}
+	if res.TargetRelease != nil {
+		d.Reason = res.TargetRelease.Reason
+	}
+
+	subject := strings.TrimSpace(r.Name + " " + res.Target)
+	switch {
+	case res.Unsupported:
+		d.Title = subject + " is no longer supported, update"
+	case res.Retracted:
+		d.Title = subject + " is retracted, update"
+	case res.Vulnerable:
+		d.Title = "Security update available"
+	case res.EOL:
+		d.Title = subject + " is going to be unsupported, update"
+	case res.Deprecated:
+		d.Title = subject + " is deprecated, update"
+	default:
+		d.Title = "Update available"
+	}
+
+	return d
+}
+
+// Render writes notice of res to w. It writes nothing when res doesn't
+// need notice (see NeedsNotice).
+func (r *Renderer) Render(w io.Writer, res *latest.CheckResponse) error {
+	if !NeedsNotice(res) {
+		return nil
+	}
+
+	d := r.NewData(res)
+	p := &painter{enabled: r.colored(w)}
+
+	tmpl := r.Template
+	if tmpl == nil {
+		tmpl = defaultTemplate
+	}
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	tmpl.Funcs(p.funcs(d.Severity))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, box(buf.String(), r.width(w), p.paint(d.Severity)))
+	return err
+}
+
+// Print writes notice of res to stderr.
+func (r *Renderer) Print(res *latest.CheckResponse) error {
+	return r.Render(os.Stderr, res)
+}
+
+func (r *Renderer) colored(w io.Writer) bool {
+	switch r.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(f)
+}
+
+func (r *Renderer) width(w io.Writer) int {
+	if r.Width > 0 {
+		return r.Width
+	}
+
+	if f, ok := w.(*os.File); ok {
+		if width := terminalWidth(f); width > 0 {
+			return width
+		}
+	}
+
+	return defaultWidth
+}
@@ -0,0 +1,145 @@
+package notice
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tcnksm/go-latest"
+)
+
+func TestRender(t *testing.T) {
+	res := &latest.CheckResponse{
+		Target:   "0.1.0",
+		Current:  "0.2.0",
+		Outdated: true,
+		Meta: &latest.Meta{
+			Message: "New version include security update",
+			URL:     "https://github.com/tcnksm/ghr/releases",
+		},
+	}
+
+	r := &Renderer{InstallHint: "brew upgrade ghr", Color: ColorNever}
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf, res); err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	expect := `╭────────────────────────────────────────────╮
+│                                            │
+│   Update available 0.1.0 → 0.2.0           │
+│   New version include security update      │
+│   https://github.com/tcnksm/ghr/releases   │
+│   Run brew upgrade ghr to update           │
+│                                            │
+╰────────────────────────────────────────────╯
+`
+	if buf.String() != expect {
+		t.Fatalf("expects\n%s\nto be\n%s", buf.String(), expect)
+	}
+}
+
+func TestRender_nothing(t *testing.T) {
+	tests := []*latest.CheckResponse{
+		nil,
+		{Target: "0.2.0", Current: "0.2.0", Latest: true},
+		{Target: "0.1.0", Status: latest.StatusSkipped, SkipReason: "CI is set"},
+		{Target: "0.1.0", Status: latest.StatusFailedSoft},
+	}
+
+	for i, res := range tests {
+		var buf bytes.Buffer
+		if err := (&Renderer{}).Render(&buf, res); err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("#%d expects nothing to be rendered: %s", i, buf.String())
+		}
+	}
+}
+
+func TestRender_color(t *testing.T) {
+	res := &latest.CheckResponse{
+		Target:      "0.1.0",
+		Current:     "0.2.0",
+		Outdated:    true,
+		EOL:         true,
+		Unsupported: true,
+	}
+
+	var buf bytes.Buffer
+	r := &Renderer{Name: "ghr", Color: ColorAlways}
+	if err := r.Render(&buf, res); err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, escRed+"╭") || !strings.Contains(out, escRed+"0.2.0"+escReset) {
+		t.Fatalf("expects critical notice to be red: %q", out)
+	}
+
+	if !strings.Contains(out, "ghr 0.1.0 is no longer supported, update") {
+		t.Fatalf("expects title of unsupported version: %q", out)
+	}
+}
+
+func TestRender_template(t *testing.T) {
+	res := &latest.CheckResponse{
+		Target:    "0.1.0",
+		Current:   "0.2.0",
+		Outdated:  true,
+		Retracted: true,
+		TargetRelease: &latest.Release{
+			Version:   "0.1.0",
+			Retracted: true,
+			Reason:    "Published accidentally",
+		},
+	}
+
+	tmpl := New("custom")
+	if _, err := tmpl.Parse(`{{.Name}}: {{.Target}} ({{.Reason}}) -> {{color .Latest}} [{{.Severity}}]`); err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	var buf bytes.Buffer
+	r := &Renderer{Name: "ghr", Template: tmpl, Color: ColorNever}
+	if err := r.Render(&buf, res); err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	if !strings.Contains(buf.String(), "│   ghr: 0.1.0 (Published accidentally) -> 0.2.0 [critical]   │") {
+		t.Fatalf("expects custom template to be used: %s", buf.String())
+	}
+}
+
+func TestBox_wrap(t *testing.T) {
+	out := box("Update available\nthis message is longer than the box", 20, func(s string) string { return s })
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if w := visibleWidth(line); w != 20 {
+			t.Fatalf("expects width of %q to be 20: %d", line, w)
+		}
+	}
+}
+
+func TestSeverityOf(t *testing.T) {
+	tests := []struct {
+		res    *latest.CheckResponse
+		expect Severity
+	}{
+		{&latest.CheckResponse{Outdated: true}, SeverityInfo},
+		{&latest.CheckResponse{EOL: true}, SeverityWarning},
+		{&latest.CheckResponse{Deprecated: true}, SeverityWarning},
+		{&latest.CheckResponse{Vulnerable: true, Advisories: []*latest.Advisory{{Severity: latest.SeverityLow}}}, SeverityWarning},
+		{&latest.CheckResponse{Vulnerable: true, Advisories: []*latest.Advisory{{Severity: latest.SeverityHigh}}}, SeverityCritical},
+		{&latest.CheckResponse{Retracted: true}, SeverityCritical},
+		{&latest.CheckResponse{Unsupported: true}, SeverityCritical},
+	}
+
+	for i, tt := range tests {
+		if got := SeverityOf(tt.res); got != tt.expect {
+			t.Fatalf("#%d expects %s to be %s", i, got, tt.expect)
+		}
+	}
+}
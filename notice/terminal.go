@@ -0,0 +1,143 @@
+package notice
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/tcnksm/go-latest/internal/term"
+)
+
+// defaultWidth is max width of the box when terminal width is unknown.
+const defaultWidth = 80
+
+// padding is spaces between border and content.
+const padding = 3
+
+// ANSI escape sequences.
+const (
+	escReset  = "\x1b[0m"
+	escBold   = "\x1b[1m"
+	escDim    = "\x1b[2m"
+	escRed    = "\x1b[31m"
+	escGreen  = "\x1b[32m"
+	escYellow = "\x1b[33m"
+	escCyan   = "\x1b[36m"
+)
+
+var escapeRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// severityColors are colors of text and border by Severity.
+var severityColors = map[Severity]string{
+	SeverityInfo:     escGreen,
+	SeverityWarning:  escYellow,
+	SeverityCritical: escRed,
+}
+
+// painter decorates text with ANSI escape sequences when it's enabled.
+type painter struct {
+	enabled bool
+}
+
+func (p *painter) wrap(esc string) func(string) string {
+	return func(s string) string {
+		if !p.enabled || s == "" {
+			return s
+		}
+		return esc + s + escReset
+	}
+}
+
+func (p *painter) paint(s Severity) func(string) string {
+	return p.wrap(severityColors[s])
+}
+
+func (p *painter) funcs(s Severity) template.FuncMap {
+	return template.FuncMap{
+		"bold":   p.wrap(escBold),
+		"dim":    p.wrap(escDim),
+		"red":    p.wrap(escRed),
+		"yellow": p.wrap(escYellow),
+		"green":  p.wrap(escGreen),
+		"cyan":   p.wrap(escCyan),
+		"color":  p.paint(s),
+	}
+}
+
+// visibleWidth returns width of s on terminal without escape sequences.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(escapeRegexp.ReplaceAllString(s, ""))
+}
+
+// wrapLine splits line into lines whose width is at most width. Words
+// longer than width are not split.
+func wrapLine(line string, width int) []string {
+	if visibleWidth(line) <= width {
+		return []string{line}
+	}
+
+	var lines []string
+	cur := ""
+	for _, word := range strings.Fields(line) {
+		switch {
+		case cur == "":
+			cur = word
+		case visibleWidth(cur)+1+visibleWidth(word) <= width:
+			cur += " " + word
+		default:
+			lines = append(lines, cur)
+			cur = word
+		}
+	}
+	return append(lines, cur)
+}
+
+// box draws rounded box around content. maxWidth is max width of the
+// box including borders. Border is decorated by paint.
+func box(content string, maxWidth int, paint func(string) string) string {
+	inner := maxWidth - 2 - padding*2
+	if inner < 1 {
+		inner = 1
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		lines = append(lines, wrapLine(strings.TrimRight(line, " "), inner)...)
+	}
+
+	width := 0
+	for _, line := range lines {
+		if w := visibleWidth(line); w > width {
+			width = w
+		}
+	}
+
+	horizontal := strings.Repeat("─", width+padding*2)
+	pad := strings.Repeat(" ", padding)
+	empty := paint("│") + strings.Repeat(" ", width+padding*2) + paint("│")
+
+	var b strings.Builder
+	b.WriteString(paint("╭"+horizontal+"╮") + "\n")
+	b.WriteString(empty + "\n")
+	for _, line := range lines {
+		fill := strings.Repeat(" ", width-visibleWidth(line))
+		b.WriteString(paint("│") + pad + line + fill + pad + paint("│") + "\n")
+	}
+	b.WriteString(empty + "\n")
+	b.WriteString(paint("╰"+horizontal+"╯") + "\n")
+
+	return b.String()
+}
+
+// terminalWidth returns width of terminal f. It returns 0 when it's
+// unknown. COLUMNS is preferred when it's set.
+func terminalWidth(f *os.File) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	return term.Width(f)
+}
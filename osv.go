@@ -0,0 +1,323 @@
+package latest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// DefaultOSVURL is URL of OSV API (https://osv.dev).
+const DefaultOSVURL = "https://api.osv.dev"
+
+// OSV is AdvisorySource which fetches vulnerabilities from OSV database
+// (https://ossf.github.io/osv-schema/). It queries OSV API (/v1/query) with
+// package and target version, or reads a local OSV export directory (JSON
+// files) for offline use when Dir is set.
+//
+//	checker := &latest.Checker{
+//	    Source:         githubTag,
+//	    AdvisorySource: &latest.OSV{Ecosystem: "Go", Package: "github.com/tcnksm/ghr"},
+//	}
+type OSV struct {
+	// Ecosystem and Package identify package in OSV. e.g., `Go` and
+	// `github.com/tcnksm/ghr`. Package MUST be set.
+	Ecosystem string
+	Package   string
+
+	// URL is OSV API URL. By default, DefaultOSVURL is used.
+	URL string
+
+	// Dir is local directory which includes OSV JSON files. If it's set,
+	// Dir is used instead of API.
+	Dir string
+
+	// Retry is policy to retry request on transient errors. By default,
+	// DefaultRetryPolicy is used.
+	Retry *RetryPolicy
+}
+
+// osvVuln is a vulnerability entry of OSV schema. Only required fields
+// are decoded.
+type osvVuln struct {
+	ID        string `json:"id"`
+	Summary   string `json:"summary"`
+	Details   string `json:"details"`
+	Withdrawn string `json:"withdrawn"`
+	Affected  []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Type   string `json:"type"`
+			Events []struct {
+				Introduced   string `json:"introduced"`
+				Fixed        string `json:"fixed"`
+				LastAffected string `json:"last_affected"`
+			} `json:"events"`
+		} `json:"ranges"`
+		Versions []string `json:"versions"`
+	} `json:"affected"`
+	References []struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"references"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+type osvQuery struct {
+	Version   string `json:"version"`
+	PageToken string `json:"page_token,omitempty"`
+	Package   struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem,omitempty"`
+	} `json:"package"`
+}
+
+type osvQueryResponse struct {
+	Vulns         []*osvVuln `json:"vulns"`
+	NextPageToken string     `json:"next_page_token"`
+}
+
+func (o *OSV) url() string {
+	if o.URL == "" {
+		return DefaultOSVURL
+	}
+
+	return o.URL
+}
+
+func (o *OSV) Validate() error {
+
+	if len(o.Package) == 0 {
+		return validationErrorf("Package must be set")
+	}
+
+	if o.Dir != "" {
+		if info, err := os.Stat(o.Dir); err != nil || !info.IsDir() {
+			return validationErrorf("%s is not directory", o.Dir)
+		}
+		return nil
+	}
+
+	if _, err := url.Parse(o.url()); err != nil {
+		return validationErrorf("%s is invalid URL: %s", o.url(), err.Error())
+	}
+
+	return nil
+}
+
+func (o *OSV) FetchAdvisories(target string) ([]*Advisory, error) {
+	targetV, err := version.NewVersion(target)
+	if err != nil {
+		return nil, &ParseError{Input: target, Err: err}
+	}
+
+	var vulns []*osvVuln
+	if o.Dir != "" {
+		vulns, err = o.readDir()
+	} else {
+		vulns, err = o.query(target)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var advisories []*Advisory
+	for _, v := range vulns {
+		if v.Withdrawn != "" {
+			continue
+		}
+
+		if a, ok := o.advisory(v, targetV); ok {
+			advisories = append(advisories, a)
+		}
+	}
+
+	return advisories, nil
+}
+
+// query queries OSV API with package and target version.
+func (o *OSV) query(target string) ([]*osvVuln, error) {
+	u, _ := url.Parse(strings.TrimSuffix(o.url(), "/") + "/v1/query")
+
+	var q osvQuery
+	q.Version = target
+	q.Package.Name = o.Package
+	q.Package.Ecosystem = o.Ecosystem
+
+	var vulns []*osvVuln
+	for {
+		body, err := json.Marshal(&q)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp *http.Response
+		err = retryPolicyOf(o.Retry).do(func() error {
+			req, err := http.NewRequest("POST", u.String(), bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			req.Header.Add("Content-Type", "application/json")
+			req.Header.Add("Accept", "application/json")
+
+			resp, err = doRequest(req)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var res osvQueryResponse
+		err = json.NewDecoder(resp.Body).Decode(&res)
+		resp.Body.Close()
+		if err != nil {
+			return nil, &ParseError{Input: u.String(), Err: err}
+		}
+
+		vulns = append(vulns, res.Vulns...)
+		if res.NextPageToken == "" {
+			return vulns, nil
+		}
+		q.PageToken = res.NextPageToken
+	}
+}
+
+// readDir reads all OSV JSON files in Dir.
+func (o *OSV) readDir() ([]*osvVuln, error) {
+	files, err := filepath.Glob(filepath.Join(o.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var vulns []*osvVuln
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		var v osvVuln
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, &ParseError{Input: f, Err: err}
+		}
+		vulns = append(vulns, &v)
+	}
+
+	return vulns, nil
+}
+
+// advisory converts OSV entry into Advisory if it affects target.
+// Affected is set to the range which includes target.
+func (o *OSV) advisory(v *osvVuln, targetV *version.Version) (*Advisory, bool) {
+	for _, affected := range v.Affected {
+		if affected.Package.Name != o.Package {
+			continue
+		}
+
+		if o.Ecosystem != "" && affected.Package.Ecosystem != o.Ecosystem {
+			continue
+		}
+
+		// Explicit version list
+		for _, verStr := range affected.Versions {
+			if av, err := version.NewVersion(verStr); err == nil && av.Equal(targetV) {
+				return o.newAdvisory(v, "= "+targetV.String(), ""), true
+			}
+		}
+
+		for _, r := range affected.Ranges {
+			if r.Type != "SEMVER" && r.Type != "ECOSYSTEM" {
+				continue
+			}
+
+			// Events are ordered. Each introduced starts an interval which
+			// is closed by following fixed or last_affected.
+			var introduced string
+			var open bool
+			for _, e := range r.Events {
+				var constraint, fixed string
+				switch {
+				case e.Introduced != "":
+					introduced, open = e.Introduced, true
+					continue
+				case e.Fixed != "" && open:
+					constraint, fixed = osvConstraint(introduced, "< "+e.Fixed), e.Fixed
+				case e.LastAffected != "" && open:
+					constraint = osvConstraint(introduced, "<= "+e.LastAffected)
+				default:
+					continue
+				}
+				open = false
+
+				if c, err := version.NewConstraint(constraint); err == nil && c.Check(targetV) {
+					return o.newAdvisory(v, constraint, fixed), true
+				}
+			}
+
+			// Interval which is not closed affects every later version.
+			if open {
+				constraint := osvConstraint(introduced, "")
+				if c, err := version.NewConstraint(constraint); err == nil && c.Check(targetV) {
+					return o.newAdvisory(v, constraint, ""), true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// osvConstraint builds constraint from introduced version and upper bound.
+// Introduced `0` means all versions.
+func osvConstraint(introduced, upper string) string {
+	var parts []string
+	if introduced != "" && introduced != "0" {
+		parts = append(parts, ">= "+introduced)
+	}
+
+	if upper != "" {
+		parts = append(parts, upper)
+	}
+
+	if len(parts) == 0 {
+		return ">= 0"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (o *OSV) newAdvisory(v *osvVuln, affected, fixed string) *Advisory {
+	summary := v.Summary
+	if summary == "" {
+		summary = strings.SplitN(strings.TrimSpace(v.Details), "\n", 2)[0]
+	}
+
+	a := &Advisory{
+		ID:       v.ID,
+		Summary:  summary,
+		Severity: strings.ToLower(v.DatabaseSpecific.Severity),
+		URL:      "https://osv.dev/vulnerability/" + v.ID,
+		Affected: affected,
+		Fixed:    fixed,
+	}
+
+	for _, ref := range v.References {
+		if ref.Type == "ADVISORY" {
+			a.URL = ref.URL
+			break
+		}
+	}
+
+	return a
+}
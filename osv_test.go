@@ -0,0 +1,198 @@
+package latest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOSV_implement(t *testing.T) {
+	var _ AdvisorySource = &OSV{}
+}
+
+func TestOSVValidate(t *testing.T) {
+
+	tests := []struct {
+		OSV       *OSV
+		expectErr bool
+	}{
+		{
+			OSV:       &OSV{Package: "github.com/tcnksm/ghr"},
+			expectErr: false,
+		},
+		{
+			OSV:       &OSV{Package: "github.com/tcnksm/ghr", Dir: "test-fixtures/osv"},
+			expectErr: false,
+		},
+		{
+			OSV:       &OSV{},
+			expectErr: true,
+		},
+		{
+			OSV:       &OSV{Package: "github.com/tcnksm/ghr", Dir: "test-fixtures/not-exist"},
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		err := tt.OSV.Validate()
+		if tt.expectErr == (err == nil) {
+			t.Fatalf("#%d Validate() expects err == nil to eq %t", i, tt.expectErr)
+		}
+	}
+}
+
+func TestOSVFetchAdvisories_dir(t *testing.T) {
+	o := &OSV{
+		Ecosystem: "Go",
+		Package:   "github.com/tcnksm/ghr",
+		Dir:       "test-fixtures/osv",
+	}
+
+	tests := []struct {
+		target string
+		expect []*Advisory
+	}{
+		{
+			target: "0.0.9",
+			expect: []*Advisory{
+				{
+					ID:       "GO-2015-0001",
+					Summary:  "Arbitrary file overwrite in ghr",
+					Severity: SeverityHigh,
+					URL:      "https://github.com/advisories/GHSA-xxxx-yyyy-zzzz",
+					Affected: "< 0.1.2",
+					Fixed:    "0.1.2",
+				},
+			},
+		},
+		{
+			target: "0.1.2",
+			expect: []*Advisory{
+				{
+					ID:       "GO-2015-0002",
+					Summary:  "Token is logged in debug output.",
+					URL:      "https://osv.dev/vulnerability/GO-2015-0002",
+					Affected: ">= 0.1.0, <= 0.1.3",
+				},
+			},
+		},
+		{
+			target: "0.2.0",
+			expect: []*Advisory{
+				{
+					ID:       "GO-2015-0001",
+					Summary:  "Arbitrary file overwrite in ghr",
+					Severity: SeverityHigh,
+					URL:      "https://github.com/advisories/GHSA-xxxx-yyyy-zzzz",
+					Affected: ">= 0.2.0, < 0.2.1",
+					Fixed:    "0.2.1",
+				},
+			},
+		},
+		{
+			target: "0.2.1",
+			expect: nil,
+		},
+	}
+
+	for i, tt := range tests {
+		advisories, err := o.FetchAdvisories(tt.target)
+		if err != nil {
+			t.Fatalf("#%d FetchAdvisories() expects error:%q to be nil", i, err.Error())
+		}
+
+		if !reflect.DeepEqual(advisories, tt.expect) {
+			t.Fatalf("#%d FetchAdvisories() expects %#v to be %#v", i, advisories, tt.expect)
+		}
+	}
+}
+
+func TestOSVFetchAdvisories_query(t *testing.T) {
+	// Fake OSV API which returns all vulnerabilities in test-fixtures/osv
+	// over 2 pages.
+	var queries []osvQuery
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var q osvQuery
+		if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		queries = append(queries, q)
+
+		files, _ := filepath.Glob("test-fixtures/osv/*.json")
+		var res osvQueryResponse
+		for _, f := range files {
+			b, _ := ioutil.ReadFile(f)
+			var v osvVuln
+			json.Unmarshal(b, &v)
+			res.Vulns = append(res.Vulns, &v)
+		}
+
+		if q.PageToken == "" {
+			res.Vulns, res.NextPageToken = res.Vulns[:1], "next"
+		} else {
+			res.Vulns = res.Vulns[1:]
+		}
+		json.NewEncoder(w).Encode(&res)
+	}))
+	defer ts.Close()
+
+	o := &OSV{
+		Ecosystem: "Go",
+		Package:   "github.com/tcnksm/ghr",
+		URL:       ts.URL,
+	}
+
+	advisories, err := o.FetchAdvisories("0.1.1")
+	if err != nil {
+		t.Fatalf("FetchAdvisories() expects error:%q to be nil", err.Error())
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("FetchAdvisories() expects number of queries %d to be 2", len(queries))
+	}
+
+	q := queries[0]
+	if q.Version != "0.1.1" || q.Package.Name != "github.com/tcnksm/ghr" || q.Package.Ecosystem != "Go" {
+		t.Fatalf("FetchAdvisories() sends unexpected query %#v", q)
+	}
+
+	var ids []string
+	for _, a := range advisories {
+		ids = append(ids, a.ID)
+	}
+
+	if expect := []string{"GO-2015-0001", "GO-2015-0002"}; !reflect.DeepEqual(ids, expect) {
+		t.Fatalf("FetchAdvisories() expects %v to be %v", ids, expect)
+	}
+}
+
+func TestChecker_advisorySource(t *testing.T) {
+	c := &Checker{
+		Source: &fakeSource{versions: []string{"0.2.1"}},
+		AdvisorySource: &OSV{
+			Ecosystem: "Go",
+			Package:   "github.com/tcnksm/ghr",
+			Dir:       "test-fixtures/osv",
+		},
+	}
+
+	res, err := c.Check("0.2.0")
+	if err != nil {
+		t.Fatalf("Check() expects error:%q to be nil", err.Error())
+	}
+
+	if !res.Vulnerable || len(res.Advisories) != 1 || res.Advisories[0].Fixed != "0.2.1" {
+		t.Fatalf("Check() expects 0.2.0 to be vulnerable and fixed in 0.2.1: %#v", res.Advisories)
+	}
+}
@@ -0,0 +1,96 @@
+package latest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tcnksm/go-latest/internal/term"
+)
+
+// Environmental variables of common opt-out conventions honored by Policy.
+const (
+	// EnvCI is set by most CI services (e.g., `CI=true`).
+	EnvCI = "CI"
+
+	// EnvNoUpdateNotifier is used by update-notifier and its ports.
+	EnvNoUpdateNotifier = "NO_UPDATE_NOTIFIER"
+
+	// EnvDoNotTrack is Console Do Not Track (https://consoledonottrack.com).
+	EnvDoNotTrack = "DO_NOT_TRACK"
+)
+
+// Policy decides whether version check should be skipped in the running
+// environment. Check is skipped when one of the following holds,
+//
+//   - EnvGoLatestDisable, EnvNoUpdateNotifier or EnvDoNotTrack is set
+//   - EnvCI is set (unless AllowCI)
+//   - stdout or stderr is not a terminal (unless AllowNonTTY)
+//   - EnvVar of the tool is set
+//   - Config returns true value for ConfigKey
+//
+// Variable whose value is `0` or `false` is treated as not set.
+type Policy struct {
+	// EnvVar is opt-out environmental variable of the tool, e.g.,
+	// `MYTOOL_NO_UPDATE_CHECK`. It's optional.
+	EnvVar string
+
+	// ConfigKey is opt-out key in config file of the tool, e.g.,
+	// `update_check.disable`. Config is called with it to look up its
+	// value. Both are optional.
+	ConfigKey string
+	Config    func(key string) string
+
+	// AllowCI runs check even in CI.
+	AllowCI bool
+
+	// AllowNonTTY runs check even when stdout or stderr is not
+	// a terminal (e.g., output is piped or redirected).
+	AllowNonTTY bool
+}
+
+// isTerminal returns true when f is a terminal. It's replaced in tests.
+var isTerminal = term.IsTerminal
+
+// SkipReason returns why check should be skipped. It returns empty
+// string when check should run.
+func (p *Policy) SkipReason() string {
+	// GOLATEST_DISABLE is honored with any value for compatibility
+	if os.Getenv(EnvGoLatestDisable) != "" {
+		return fmt.Sprintf("%s is set", EnvGoLatestDisable)
+	}
+
+	for _, env := range []string{EnvNoUpdateNotifier, EnvDoNotTrack, p.EnvVar} {
+		if env != "" && isTrue(os.Getenv(env)) {
+			return fmt.Sprintf("%s is set", env)
+		}
+	}
+
+	if p.ConfigKey != "" && p.Config != nil && isTrue(p.Config(p.ConfigKey)) {
+		return fmt.Sprintf("%s is set in config", p.ConfigKey)
+	}
+
+	if !p.AllowCI && isTrue(os.Getenv(EnvCI)) {
+		return fmt.Sprintf("running in CI (%s is set)", EnvCI)
+	}
+
+	if !p.AllowNonTTY {
+		if !isTerminal(os.Stdout) {
+			return "stdout is not a terminal"
+		}
+		if !isTerminal(os.Stderr) {
+			return "stderr is not a terminal"
+		}
+	}
+
+	return ""
+}
+
+// isTrue returns true when value is set and it's not `0` or `false`.
+func isTrue(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package latest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tcnksm/go-latest/internal/term"
+)
+
+func TestPolicySkipReason(t *testing.T) {
+	defer func() { isTerminal = term.IsTerminal }()
+
+	envs := []string{EnvGoLatestDisable, EnvCI, EnvNoUpdateNotifier, EnvDoNotTrack, "MYTOOL_NO_UPDATE_CHECK"}
+	for _, env := range envs {
+		if v, ok := os.LookupEnv(env); ok {
+			defer os.Setenv(env, v)
+		} else {
+			defer os.Unsetenv(env)
+		}
+	}
+
+	config := map[string]string{"update_check.disable": "true"}
+
+	tests := []struct {
+		policy   *Policy
+		env      map[string]string
+		terminal bool
+		expect   string
+	}{
+		{policy: &Policy{}, terminal: true, expect: ""},
+		{policy: &Policy{}, env: map[string]string{EnvGoLatestDisable: "0"}, terminal: true, expect: "GOLATEST_DISABLE is set"},
+		{policy: &Policy{}, env: map[string]string{EnvNoUpdateNotifier: "1"}, terminal: true, expect: "NO_UPDATE_NOTIFIER is set"},
+		{policy: &Policy{}, env: map[string]string{EnvDoNotTrack: "1"}, terminal: true, expect: "DO_NOT_TRACK is set"},
+		{policy: &Policy{}, env: map[string]string{EnvDoNotTrack: "0"}, terminal: true, expect: ""},
+		{policy: &Policy{}, env: map[string]string{EnvCI: "true"}, terminal: true, expect: "running in CI (CI is set)"},
+		{policy: &Policy{}, env: map[string]string{EnvCI: "false"}, terminal: true, expect: ""},
+		{policy: &Policy{AllowCI: true}, env: map[string]string{EnvCI: "true"}, terminal: true, expect: ""},
+		{policy: &Policy{}, terminal: false, expect: "stdout is not a terminal"},
+		{policy: &Policy{AllowNonTTY: true}, terminal: false, expect: ""},
+		{
+			policy:   &Policy{EnvVar: "MYTOOL_NO_UPDATE_CHECK"},
+			env:      map[string]string{"MYTOOL_NO_UPDATE_CHECK": "yes"},
+			terminal: true,
+			expect:   "MYTOOL_NO_UPDATE_CHECK is set",
+		},
+		{
+			policy:   &Policy{ConfigKey: "update_check.disable", Config: func(key string) string { return config[key] }},
+			terminal: true,
+			expect:   "update_check.disable is set in config",
+		},
+	}
+
+	for i, tt := range tests {
+		for _, env := range envs {
+			os.Unsetenv(env)
+		}
+		for k, v := range tt.env {
+			os.Setenv(k, v)
+		}
+		terminal := tt.terminal
+		isTerminal = func(*os.File) bool { return terminal }
+
+		if got := tt.policy.SkipReason(); got != tt.expect {
+			t.Fatalf("#%d expects %q to be %q", i, got, tt.expect)
+		}
+	}
+}
+
+func TestPolicySkipReason_devNull(t *testing.T) {
+	// /dev/null is a character device but not a terminal
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("%s is not available: %s", os.DevNull, err)
+	}
+	defer devNull.Close()
+
+	defer func(f *os.File) { os.Stdout = f }(os.Stdout)
+	os.Stdout = devNull
+
+	for _, env := range []string{EnvGoLatestDisable, EnvNoUpdateNotifier, EnvDoNotTrack} {
+		if v, ok := os.LookupEnv(env); ok {
+			defer os.Setenv(env, v)
+			os.Unsetenv(env)
+		}
+	}
+
+	p := &Policy{AllowCI: true}
+	if got, expect := p.SkipReason(), "stdout is not a terminal"; got != expect {
+		t.Fatalf("expects %q to be %q", got, expect)
+	}
+}
+
+func TestCheck_policy(t *testing.T) {
+	defer func() { isTerminal = term.IsTerminal }()
+	isTerminal = func(*os.File) bool { return false }
+
+	c := &Checker{
+		Source: &fakeSource{versions: []string{"1.0.0"}},
+		Policy: &Policy{},
+	}
+
+	res, err := c.Check("0.1.0")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	if res.Status != StatusSkipped || res.SkipReason != "stdout is not a terminal" || res.Target != "0.1.0" || res.Outdated {
+		t.Fatalf("expects check to be skipped: %#v", res)
+	}
+
+	// Without policy, check runs in non-terminal
+	c.Policy = nil
+	res, err = c.Check("0.1.0")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	if res.Status != StatusChecked || !res.Outdated {
+		t.Fatalf("expects check to run: %#v", res)
+	}
+}
@@ -0,0 +1,161 @@
+package latest
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Release is a release record of a single version on source. Source
+// fills fields as much as it knows.
+type Release struct {
+	// Name is original name of the release on source (e.g., tag name)
+	// before FixVersionStrFunc is applied.
+	Name string
+
+	// Version is version string after FixVersionStrFunc is applied.
+	// It's included in Versions (or Malformeds) of FetchResponse.
+	Version string
+
+	// Date is published date. It's zero when it's unknown.
+	Date time.Time
+
+	// URL is URL where the release is published.
+	URL string
+
+	// Commit is commit SHA (or ref) which the release points to.
+	Commit string
+
+	// Notes is release notes.
+	Notes string
+
+	// Assets is downloadable files of the release.
+	Assets []*Asset
+
+	// Retracted is true when the release is withdrawn after it's
+	// published (e.g., retracted Go module version or yanked package).
+	Retracted bool
+
+	// Deprecated is true when the release is still available but its use
+	// is discouraged (e.g., deprecated npm package version).
+	Deprecated bool
+
+	// Reason is why the release is retracted or deprecated.
+	Reason string
+
+	// Rollout is staged rollout of the release. It's nil when the
+	// release is reported to all installs.
+	Rollout *Rollout
+
+	// date fetches Date lazily. See PublishDate.
+	date func() (time.Time, error)
+}
+
+// Retraction marks a published version as retracted (or deprecated)
+// with reason. It's reported by source and applied to Release.
+type Retraction struct {
+	Version    string `json:"version"`
+	Reason     string `json:"reason,omitempty"`
+	Deprecated bool   `json:"deprecated,omitempty"`
+}
+
+// Asset is a downloadable file of Release.
+type Asset struct {
+	Name        string
+	URL         string
+	Size        int64
+	ContentType string
+}
+
+// addRelease parses verStr and adds it to Versions (or Malformeds if it
+// can not be parsed) with Release record. name is original name of the
+// release. Returned Release can be used to fill other fields.
+func (fr *FetchResponse) addRelease(name, verStr string) *Release {
+	v, err := version.NewVersion(verStr)
+	if err != nil {
+		fr.Malformeds = append(fr.Malformeds, verStr)
+	} else {
+		fr.Versions = append(fr.Versions, v)
+	}
+
+	r := &Release{Name: name, Version: verStr}
+	fr.Releases = append(fr.Releases, r)
+	return r
+}
+
+// retract marks Release of each Retraction as retracted or deprecated.
+// Release is matched by version parsed by the default scheme (see
+// findRelease) because source doesn't know Checker.Scheme. Retracted
+// version was published once, so unknown version is added.
+func (fr *FetchResponse) retract(rs []*Retraction) {
+	for _, rt := range rs {
+		r := findRelease(fr.Releases, HashicorpVersion{}, rt.Version)
+		if r == nil {
+			r = fr.addRelease(rt.Version, rt.Version)
+		}
+
+		if rt.Deprecated {
+			r.Deprecated = true
+		} else {
+			r.Retracted = true
+		}
+		r.Reason = rt.Reason
+	}
+}
+
+// PublishDate returns Date. Some sources (e.g., GithubTag with
+// CommitDate) don't fill Date on Fetch because it costs an additional
+// request per release. Then it's fetched on first call.
+func (r *Release) PublishDate() (time.Time, error) {
+	if r.date != nil {
+		date, err := r.date()
+		if err != nil {
+			return time.Time{}, err
+		}
+		r.Date, r.date = date, nil
+	}
+	return r.Date, nil
+}
+
+// withdrawn returns true when r is retracted or deprecated. It's safe
+// to call with nil.
+func (r *Release) withdrawn() bool {
+	return r != nil && (r.Retracted || r.Deprecated)
+}
+
+// findRelease returns Release whose Version is verStr. If there is no
+// exact match, Release whose Version is equal as version parsed by
+// scheme (e.g., `v1.3.0` for `1.3.0`) is returned. It returns nil if
+// there is no such Release.
+func findRelease(releases []*Release, scheme VersionScheme, verStr string) *Release {
+	for _, r := range releases {
+		if r.Version == verStr {
+			return r
+		}
+	}
+
+	for _, r := range releases {
+		if sameVersion(scheme, r.Version, verStr) {
+			return r
+		}
+	}
+	return nil
+}
+
+// sameVersion returns true when a and b are the same string or equal as
+// versions parsed by scheme.
+func sameVersion(scheme VersionScheme, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	va, err := scheme.Parse(a)
+	if err != nil {
+		return false
+	}
+	vb, err := scheme.Parse(b)
+	if err != nil {
+		return false
+	}
+	return va.Compare(vb) == 0
+}
@@ -0,0 +1,263 @@
+package latest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheck_currentRelease(t *testing.T) {
+	githubServer := fakeGithubServer("/repos/tcnksm/ghr/releases", "test-fixtures/github_releases.json")
+	defer githubServer.Close()
+
+	tagServer := fakeGithubServer("/repos/tcnksm/ghr/tags", "test-fixtures/github_tags.json")
+	defer tagServer.Close()
+
+	feedServer := fakeServer("test-fixtures/feed.atom")
+	defer feedServer.Close()
+
+	jsonServer := fakeServer("test-fixtures/releases.json")
+	defer jsonServer.Close()
+
+	tests := []struct {
+		source Source
+		expect *Release
+	}{
+		{
+			source: &GithubRelease{
+				Owner:             "tcnksm",
+				Repository:        "ghr",
+				URL:               githubServer.URL,
+				FixVersionStrFunc: DeleteFrontV(),
+			},
+			expect: &Release{
+				Name:    "v0.2.0",
+				Version: "0.2.0",
+				Date:    time.Date(2015, 5, 1, 0, 0, 0, 0, time.UTC),
+				URL:     "https://github.com/tcnksm/ghr/releases/tag/v0.2.0",
+				Commit:  "940bd336248efae0f9ee5bc7b2d5c985887b16de",
+				Notes:   "Add parallel upload.\r\n\r\nMinimum-Version: 0.1.2\r\nEnd-Of-Life: 2015-06-01\r\n",
+				Assets: []*Asset{
+					{
+						Name:        "ghr_v0.2.0_linux_amd64.zip",
+						URL:         "https://github.com/tcnksm/ghr/releases/download/v0.2.0/ghr_v0.2.0_linux_amd64.zip",
+						Size:        1024,
+						ContentType: "application/zip",
+					},
+				},
+			},
+		},
+		{
+			source: &GithubTag{
+				Owner:             "tcnksm",
+				Repository:        "ghr",
+				URL:               tagServer.URL,
+				FixVersionStrFunc: DeleteFrontV(),
+			},
+			expect: &Release{
+				Name:    "v0.3.0-rc1",
+				Version: "0.3.0-rc1",
+				Commit:  "c3d0be41ecbe669545ee3e94d31ed9a4bc91ee3c",
+			},
+		},
+		{
+			source: &Feed{
+				URL:               feedServer.URL,
+				FixVersionStrFunc: DeleteFrontV(),
+			},
+			expect: &Release{
+				Name:    "v0.2.0",
+				Version: "0.2.0",
+				Date:    time.Date(2015, 4, 14, 1, 0, 0, 0, time.UTC),
+				URL:     "https://github.com/tcnksm/ghr/releases/tag/v0.2.0",
+				Notes:   "Add parallel upload",
+			},
+		},
+		{
+			source: &JSON{
+				URL: jsonServer.URL,
+			},
+			expect: &Release{
+				Name:    "1.5.0",
+				Version: "1.5.0",
+				Date:    time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC),
+				URL:     "http://example.com/1.5.0",
+				Notes:   "Parallel upload",
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		res, err := Check(tt.source, "0.1.0")
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		r := res.CurrentRelease
+		if r == nil {
+			t.Fatalf("#%d expects CurrentRelease to be set", i)
+		}
+
+		// Compare date separately since location may differ
+		if !r.Date.Equal(tt.expect.Date) {
+			t.Fatalf("#%d expects %s to be %s", i, r.Date, tt.expect.Date)
+		}
+		got := *r
+		got.Date = tt.expect.Date
+
+		if !reflect.DeepEqual(&got, tt.expect) {
+			t.Fatalf("#%d expects %#v to be %#v", i, &got, tt.expect)
+		}
+	}
+}
+
+func TestCheck_currentReleaseScheme(t *testing.T) {
+	s := &fakeSource{malformeds: []string{"1:0.9-1", "1.0-1"}}
+	s.releases = []*Release{
+		{Name: "debian/1:0.9-1", Version: "1:0.9-1", Notes: "epoch bump"},
+		{Name: "debian/1.0-1", Version: "1.0-1"},
+	}
+
+	res, err := (&Checker{Source: s, Scheme: Debian{}}).Check("1.0-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.CurrentRelease == nil || res.CurrentRelease.Name != "debian/1:0.9-1" {
+		t.Fatalf("expects %#v to be debian/1:0.9-1", res.CurrentRelease)
+	}
+}
+
+func TestCheck_retracted(t *testing.T) {
+	ts := fakeServer("test-fixtures/retractions.json")
+	defer ts.Close()
+
+	tests := []struct {
+		target           string
+		expectOutdated   bool
+		expectLatest     bool
+		expectRetracted  bool
+		expectDeprecated bool
+		expectReason     string
+	}{
+		{target: "1.3.0", expectLatest: true},
+		{target: "1.2.0", expectOutdated: true, expectRetracted: true, expectReason: "Data loss on upgrade"},
+		{target: "1.1.0", expectOutdated: true, expectDeprecated: true, expectReason: "Use 1.3.0 instead"},
+		{target: "1.0.0", expectOutdated: true},
+	}
+
+	for i, tt := range tests {
+		res, err := Check(&JSON{URL: ts.URL}, tt.target)
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Current != "1.3.0" {
+			t.Fatalf("#%d expects %q to be 1.3.0", i, res.Current)
+		}
+
+		if res.Outdated != tt.expectOutdated || res.Latest != tt.expectLatest {
+			t.Fatalf("#%d expects outdated, latest (%t, %t) to be (%t, %t)", i,
+				res.Outdated, res.Latest, tt.expectOutdated, tt.expectLatest)
+		}
+
+		if res.Retracted != tt.expectRetracted || res.Deprecated != tt.expectDeprecated {
+			t.Fatalf("#%d expects retracted, deprecated (%t, %t) to be (%t, %t)", i,
+				res.Retracted, res.Deprecated, tt.expectRetracted, tt.expectDeprecated)
+		}
+
+		reason := ""
+		if res.TargetRelease != nil {
+			reason = res.TargetRelease.Reason
+		}
+		if reason != tt.expectReason {
+			t.Fatalf("#%d expects %q to be %q", i, reason, tt.expectReason)
+		}
+	}
+}
+
+func TestFetchResponseRetract(t *testing.T) {
+	fr := newFetchResponse()
+	fr.addRelease("v1.2.0", "v1.2.0")
+	fr.addRelease("v1.3.0", "v1.3.0")
+
+	fr.retract([]*Retraction{
+		{Version: "1.3.0", Reason: "Broken build"},
+		{Version: "1.4.0", Deprecated: true},
+	})
+
+	var got []string
+	for _, r := range fr.Releases {
+		got = append(got, fmt.Sprintf("%s:%t:%t", r.Version, r.Retracted, r.Deprecated))
+	}
+
+	// 1.3.0 is v1.3.0, and only unknown 1.4.0 is added
+	expect := []string{"v1.2.0:false:false", "v1.3.0:true:false", "1.4.0:false:true"}
+	if !reflect.DeepEqual(got, expect) {
+		t.Fatalf("expects %v to be %v", got, expect)
+	}
+
+	if len(fr.Versions) != 3 {
+		t.Fatalf("expects 3 versions: %v", fr.Versions)
+	}
+}
+
+func TestCheck_retractedCurrent(t *testing.T) {
+	tests := []struct {
+		releases      []*Release
+		target        string
+		expectCurrent string
+		expectNew     bool
+		expectErr     error
+	}{
+		{
+			releases: []*Release{
+				{Version: "1.0.0"},
+				{Version: "1.1.0", Retracted: true},
+			},
+			target:        "1.0.0",
+			expectCurrent: "1.0.0",
+		},
+		{
+			// Target greater than Current is still moved off when retracted
+			releases: []*Release{
+				{Version: "1.0.0"},
+				{Version: "1.1.0", Retracted: true},
+			},
+			target:        "1.1.0",
+			expectCurrent: "1.0.0",
+		},
+		{
+			releases: []*Release{
+				{Version: "1.0.0", Deprecated: true},
+				{Version: "1.1.0", Retracted: true},
+			},
+			target:    "1.0.0",
+			expectErr: ErrNoVersions,
+		},
+	}
+
+	for i, tt := range tests {
+		s := &fakeSource{releases: tt.releases}
+		for _, r := range tt.releases {
+			s.versions = append(s.versions, r.Version)
+		}
+
+		res, err := Check(s, tt.target)
+		if tt.expectErr != nil {
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("#%d expects err %v to be %v", i, err, tt.expectErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Current != tt.expectCurrent || res.New {
+			t.Fatalf("#%d expects current %q to be %q and not new", i, res.Current, tt.expectCurrent)
+		}
+	}
+}
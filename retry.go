@@ -0,0 +1,147 @@
+package latest
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// DefaultRetryPolicy is RetryPolicy used by sources which don't set
+// their own policy. By default it's nil, and request is sent only once.
+var DefaultRetryPolicy *RetryPolicy
+
+// Default values of RetryPolicy.
+const (
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// defaultRetryableStatus is HTTP status codes which are retried when
+// RetryPolicy.RetryableStatus is empty.
+var defaultRetryableStatus = []int{500, 502, 503, 504}
+
+// sleep is used to wait between attempts. It's replaced in tests.
+var sleep = time.Sleep
+
+// RetryPolicy is policy to retry request to source on transient errors.
+// Request is retried on NetworkError and StatusError whose status code
+// is in RetryableStatus. Wait between attempts grows exponentially from
+// InitialBackoff to MaxBackoff with random jitter.
+//
+// RateLimitError is retried only when its reset time is within MaxWait.
+// Otherwise it's returned immediately.
+type RetryPolicy struct {
+	// MaxAttempts is max number of requests including the first one.
+	// If it's less than 1, request is sent only once.
+	MaxAttempts int
+
+	// InitialBackoff and MaxBackoff are wait before the second attempt and
+	// upper limit of wait. By default, 500ms and 30s are used.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// RetryableStatus is HTTP status codes to retry.
+	// By default, 500, 502, 503 and 504 are retried.
+	RetryableStatus []int
+
+	// MaxWait is max duration to wait until rate limit is reset.
+	// If it's zero, RateLimitError is returned without waiting.
+	MaxWait time.Duration
+}
+
+// retryPolicyOf returns p or DefaultRetryPolicy if p is nil.
+func retryPolicyOf(p *RetryPolicy) *RetryPolicy {
+	if p == nil {
+		return DefaultRetryPolicy
+	}
+
+	return p
+}
+
+// do calls fn until it succeeds or it returns error which should not be
+// retried. nil RetryPolicy calls fn only once.
+func (p *RetryPolicy) do(fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || p == nil || attempt >= p.MaxAttempts {
+			return err
+		}
+
+		wait, ok := p.wait(err, attempt)
+		if !ok {
+			return err
+		}
+		sleep(wait)
+	}
+}
+
+// wait returns duration to wait before next attempt after attempt-th
+// attempt fails with err. It returns false if err should not be retried.
+func (p *RetryPolicy) wait(err error, attempt int) (time.Duration, bool) {
+	var rle *RateLimitError
+	if errors.As(err, &rle) {
+		if rle.Reset.IsZero() {
+			return 0, false
+		}
+
+		wait := rle.Reset.Sub(timeNow())
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, wait <= p.MaxWait
+	}
+
+	var se *StatusError
+	if errors.As(err, &se) {
+		if !containsInt(p.retryableStatus(), se.StatusCode) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	if errors.Is(err, ErrNetwork) {
+		return p.backoff(attempt), true
+	}
+
+	return 0, false
+}
+
+// backoff returns exponential backoff with jitter. Returned value is
+// between half and full of InitialBackoff * 2^(attempt-1).
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max := p.InitialBackoff, p.MaxBackoff
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+
+	d := initial
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+func (p *RetryPolicy) retryableStatus() []int {
+	if len(p.RetryableStatus) == 0 {
+		return defaultRetryableStatus
+	}
+
+	return p.RetryableStatus
+}
+
+func containsInt(list []int, n int) bool {
+	for _, l := range list {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
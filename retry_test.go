@@ -0,0 +1,218 @@
+package latest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// flakyHandler responds fail for the first n requests and then
+// responds fixture.
+func flakyHandler(n int, fail http.HandlerFunc, fixture string) (http.Handler, *int) {
+	count := 0
+	ok := fakeHandler(fixture)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count <= n {
+			fail(w, r)
+			return
+		}
+		ok.ServeHTTP(w, r)
+	}), &count
+}
+
+// fakeSleep replaces sleep and records durations.
+func fakeSleep() (*[]time.Duration, func()) {
+	var slept []time.Duration
+	sleep = func(d time.Duration) {
+		slept = append(slept, d)
+	}
+	return &slept, func() { sleep = time.Sleep }
+}
+
+func unavailable(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusServiceUnavailable)
+}
+
+func TestRetryPolicy_status(t *testing.T) {
+	slept, restore := fakeSleep()
+	defer restore()
+
+	tests := []struct {
+		policy        *RetryPolicy
+		failures      int
+		expectErr     bool
+		expectCount   int
+		expectRetries int
+	}{
+		{nil, 1, true, 1, 0},
+		{&RetryPolicy{MaxAttempts: 3}, 2, false, 3, 2},
+		{&RetryPolicy{MaxAttempts: 3}, 3, true, 3, 2},
+		{&RetryPolicy{MaxAttempts: 3, RetryableStatus: []int{500}}, 1, true, 1, 0},
+	}
+
+	for i, tt := range tests {
+		*slept = nil
+		h, count := flakyHandler(tt.failures, unavailable, "test-fixtures/default.json")
+		ts := httptest.NewServer(h)
+
+		j := &JSON{URL: ts.URL, Retry: tt.policy}
+		_, err := j.Fetch()
+		ts.Close()
+
+		if (err != nil) != tt.expectErr {
+			t.Fatalf("#%d expects err to be %t: %s", i, tt.expectErr, err)
+		}
+
+		if *count != tt.expectCount {
+			t.Fatalf("#%d expects %d requests to be %d", i, *count, tt.expectCount)
+		}
+
+		if len(*slept) != tt.expectRetries {
+			t.Fatalf("#%d expects %d retries to be %d", i, len(*slept), tt.expectRetries)
+		}
+	}
+}
+
+func TestRetryPolicy_default(t *testing.T) {
+	_, restore := fakeSleep()
+	defer restore()
+
+	DefaultRetryPolicy = &RetryPolicy{MaxAttempts: 2}
+	defer func() { DefaultRetryPolicy = nil }()
+
+	h, count := flakyHandler(1, unavailable, "test-fixtures/default.html")
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	f := &HTML{URL: ts.URL}
+	if _, err := f.Fetch(); err != nil {
+		t.Fatal(err)
+	}
+
+	if *count != 2 {
+		t.Fatalf("expects %d requests to be 2", *count)
+	}
+}
+
+func TestRetryPolicy_rateLimit(t *testing.T) {
+	slept, restore := fakeSleep()
+	defer restore()
+
+	tests := []struct {
+		maxWait     time.Duration
+		retryAfter  int
+		expectErr   bool
+		expectSlept time.Duration
+	}{
+		{0, 10, true, 0},
+		{time.Minute, 10, false, 10 * time.Second},
+		{time.Minute, 120, true, 0},
+	}
+
+	now := time.Date(2015, 4, 14, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	for i, tt := range tests {
+		*slept = nil
+		h, _ := flakyHandler(1, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", strconv.Itoa(tt.retryAfter))
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, "test-fixtures/default.json")
+		ts := httptest.NewServer(h)
+
+		j := &JSON{URL: ts.URL, Retry: &RetryPolicy{MaxAttempts: 3, MaxWait: tt.maxWait}}
+		_, err := j.Fetch()
+		ts.Close()
+
+		if tt.expectErr {
+			if !errors.Is(err, ErrRateLimited) {
+				t.Fatalf("#%d expects %q to be %q", i, err, ErrRateLimited)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if len(*slept) != 1 || (*slept)[0] != tt.expectSlept {
+			t.Fatalf("#%d expects %v to be [%s]", i, *slept, tt.expectSlept)
+		}
+	}
+}
+
+func TestRetryPolicy_githubRateLimit(t *testing.T) {
+	_, restore := fakeSleep()
+	defer restore()
+
+	tests := []struct {
+		reset     time.Time
+		expectErr bool
+	}{
+		{time.Now().Add(time.Hour), true},
+		{time.Now().Add(-time.Second), false},
+	}
+
+	for i, tt := range tests {
+		h, _ := flakyHandler(1, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(tt.reset.Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"API rate limit exceeded for 127.0.0.1."}`))
+		}, "test-fixtures/github_releases.json")
+		mux := http.NewServeMux()
+		mux.Handle("/repos/tcnksm/ghr/releases", h)
+		ts := httptest.NewServer(mux)
+
+		g := &GithubRelease{
+			Owner:      "tcnksm",
+			Repository: "ghr",
+			URL:        ts.URL,
+			Retry:      &RetryPolicy{MaxAttempts: 2, MaxWait: time.Minute},
+		}
+		_, err := g.Fetch()
+		ts.Close()
+
+		if !tt.expectErr {
+			if err != nil {
+				t.Fatalf("#%d expects err to be nil: %s", i, err)
+			}
+			continue
+		}
+
+		var rle *RateLimitError
+		if !errors.As(err, &rle) {
+			t.Fatalf("#%d expects %q to be RateLimitError", i, err)
+		}
+
+		if rle.Reset.Unix() != tt.reset.Unix() {
+			t.Fatalf("#%d expects %s to be %s", i, rle.Reset, tt.reset)
+		}
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 4 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 500 * time.Millisecond, time.Second},
+		{2, time.Second, 2 * time.Second},
+		{3, 2 * time.Second, 4 * time.Second},
+		{10, 2 * time.Second, 4 * time.Second},
+	}
+
+	for i, tt := range tests {
+		d := p.backoff(tt.attempt)
+		if d < tt.min || d > tt.max {
+			t.Fatalf("#%d expects %s to be between %s and %s", i, d, tt.min, tt.max)
+		}
+	}
+}
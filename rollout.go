@@ -0,0 +1,191 @@
+package latest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Rollout is staged rollout of a release. The release is reported only
+// to installs whose bucket is less than the percentage at the time.
+// Percentage ramps up linearly from Percentage at Start to 100 at End.
+//
+//	{"percentage": 10, "start": "2015-06-01T00:00:00Z", "end": "2015-06-08T00:00:00Z"}
+type Rollout struct {
+	// Version is version string of the release. It's used to match
+	// rollout with release. In `rollout` of JSON response, it can be
+	// omitted for the version in the response.
+	Version string `json:"version,omitempty"`
+
+	// Percentage is percentage (0-100) of installs to which the release
+	// is reported (until Start when End is set).
+	Percentage float64 `json:"percentage"`
+
+	// Start and End are when percentage starts to ramp up and when it
+	// reaches 100. If End is zero, Percentage is used forever. If Start
+	// is zero, Percentage is used until End.
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+// MarshalJSON omits Start and End when they are zero. `omitempty` has no
+// effect on time.Time, and zero time would be read as a real timestamp
+// by other clients.
+func (r Rollout) MarshalJSON() ([]byte, error) {
+	// rollout doesn't have MarshalJSON, so it doesn't recurse
+	type rollout Rollout
+	v := struct {
+		*rollout
+		Start *time.Time `json:"start,omitempty"`
+		End   *time.Time `json:"end,omitempty"`
+	}{rollout: (*rollout)(&r)}
+
+	if !r.Start.IsZero() {
+		v.Start = &r.Start
+	}
+	if !r.End.IsZero() {
+		v.End = &r.End
+	}
+
+	return json.Marshal(v)
+}
+
+// RolloutPolicy is used by Checker to report releases in staged rollout
+// only to selected installs. Each install is bucketed deterministically
+// by InstallID, so the same install gets the same answer every time.
+type RolloutPolicy struct {
+	// InstallID is random ID of the install. It MUST be persisted, e.g.,
+	// by LoadInstallID.
+	InstallID string
+
+	// Schedule is rollout of releases configured by client. It's used
+	// instead of rollout reported by source for the same version.
+	Schedule []*Rollout
+}
+
+// percentage returns rollout percentage at now.
+func (r *Rollout) percentage(now time.Time) float64 {
+	switch {
+	case r.End.IsZero() || now.Before(r.Start):
+		return r.Percentage
+	case !now.Before(r.End):
+		return 100
+	case r.Start.IsZero():
+		return r.Percentage
+	}
+
+	ratio := float64(now.Sub(r.Start)) / float64(r.End.Sub(r.Start))
+	return r.Percentage + (100-r.Percentage)*ratio
+}
+
+// rollout returns rollout of release. Schedule of policy is preferred,
+// and it's matched by version parsed by scheme (e.g., `v1.3.0` for
+// `1.3.0`). It returns nil when the release is not in staged rollout.
+func (p *RolloutPolicy) rollout(r *Release, scheme VersionScheme) *Rollout {
+	for _, ro := range p.Schedule {
+		if sameVersion(scheme, ro.Version, r.Version) {
+			return ro
+		}
+	}
+	return r.Rollout
+}
+
+// selected returns true when the install is in the rollout of release
+// at now. Release which is not in staged rollout is always selected.
+func (p *RolloutPolicy) selected(r *Release, scheme VersionScheme, now time.Time) bool {
+	ro := p.rollout(r, scheme)
+	if ro == nil {
+		return true
+	}
+	return rolloutBucket(p.InstallID, r.Version) < ro.percentage(now)
+}
+
+// rolloutBucket returns bucket (0 <= bucket < 100) of the install for
+// the version. Version is included so that different installs are
+// selected first for each release.
+func rolloutBucket(installID, version string) float64 {
+	sum := sha256.Sum256([]byte(installID + "/" + version))
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53) * 100
+}
+
+// rolloutVersions returns versions reported to the install by policy.
+func rolloutVersions(versions []schemeCandidate, releases []*Release, scheme VersionScheme, p *RolloutPolicy, now time.Time) []schemeCandidate {
+	selected := make([]schemeCandidate, 0, len(versions))
+	for _, v := range versions {
+		if r := findRelease(releases, scheme, v.raw); r != nil && !p.selected(r, scheme, now) {
+			continue
+		}
+		selected = append(selected, v)
+	}
+	return selected
+}
+
+// heldBack returns true when a version greater than currentV (or any
+// version when currentV is nil) is not selected by rollout.
+func heldBack(versions, selected []schemeCandidate, currentV SchemeVersion) bool {
+	in := make(map[string]bool, len(selected))
+	for _, v := range selected {
+		in[v.raw] = true
+	}
+
+	for _, v := range versions {
+		if (currentV == nil || v.Compare(currentV) > 0) && !in[v.raw] {
+			return true
+		}
+	}
+	return false
+}
+
+// NewInstallID returns new random install ID.
+func NewInstallID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// LoadInstallID reads install ID from file at path. If the file doesn't
+// exist, it creates new install ID and saves it to the file.
+func LoadInstallID(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err == nil {
+		if id := strings.TrimSpace(string(b)); id != "" {
+			return id, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	id, err := NewInstallID()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(path, []byte(id+"\n"), 0600); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// InstallIDPath returns default path of install ID file of the tool
+// name in user config directory (e.g., `~/.config/NAME/install_id`).
+func InstallIDPath(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name, "install_id"), nil
+}
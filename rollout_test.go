@@ -0,0 +1,226 @@
+package latest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRolloutPercentage(t *testing.T) {
+	start := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 10)
+
+	tests := []struct {
+		rollout *Rollout
+		now     time.Time
+		expect  float64
+	}{
+		{&Rollout{Percentage: 10}, start, 10},
+		{&Rollout{Percentage: 10, Start: start, End: end}, start.Add(-time.Hour), 10},
+		{&Rollout{Percentage: 10, Start: start, End: end}, start, 10},
+		{&Rollout{Percentage: 10, Start: start, End: end}, start.AddDate(0, 0, 5), 55},
+		{&Rollout{Percentage: 10, Start: start, End: end}, end, 100},
+		{&Rollout{Percentage: 10, End: end}, start, 10},
+		{&Rollout{Percentage: 10, End: end}, end.Add(time.Hour), 100},
+	}
+
+	for i, tt := range tests {
+		if got := tt.rollout.percentage(tt.now); got != tt.expect {
+			t.Fatalf("#%d expects %f to be %f", i, got, tt.expect)
+		}
+	}
+}
+
+func TestRolloutBucket(t *testing.T) {
+	if rolloutBucket("install", "3.0.0") != rolloutBucket("install", "3.0.0") {
+		t.Fatalf("expects bucket to be deterministic")
+	}
+
+	// Buckets should be distributed uniformly
+	selected := 0
+	for i := 0; i < 10000; i++ {
+		b := rolloutBucket(fmt.Sprintf("install-%d", i), "3.0.0")
+		if b < 0 || b >= 100 {
+			t.Fatalf("expects %f to be in [0, 100)", b)
+		}
+		if b < 10 {
+			selected++
+		}
+	}
+
+	if selected < 800 || selected > 1200 {
+		t.Fatalf("expects about 1000 of 10000 installs to be selected: %d", selected)
+	}
+}
+
+func TestRolloutMarshalJSON(t *testing.T) {
+	start := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rollout *Rollout
+		expect  string
+	}{
+		{
+			rollout: &Rollout{Percentage: 10},
+			expect:  `{"percentage":10}`,
+		},
+		{
+			rollout: &Rollout{Version: "3.0.0", Percentage: 10, Start: start},
+			expect:  `{"version":"3.0.0","percentage":10,"start":"2015-06-01T00:00:00Z"}`,
+		},
+		{
+			rollout: &Rollout{Percentage: 10, Start: start, End: start.AddDate(0, 0, 7)},
+			expect:  `{"percentage":10,"start":"2015-06-01T00:00:00Z","end":"2015-06-08T00:00:00Z"}`,
+		},
+	}
+
+	for i, tt := range tests {
+		b, err := json.Marshal(tt.rollout)
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if string(b) != tt.expect {
+			t.Fatalf("#%d expects %s to be %s", i, b, tt.expect)
+		}
+
+		var ro Rollout
+		if err := json.Unmarshal(b, &ro); err != nil || !reflect.DeepEqual(&ro, tt.rollout) {
+			t.Fatalf("#%d expects %#v to be %#v (%v)", i, &ro, tt.rollout, err)
+		}
+	}
+}
+
+func TestJSONFetch_rollout(t *testing.T) {
+	ts := fakeServer("test-fixtures/rollout.json")
+	defer ts.Close()
+
+	fr, err := (&JSON{URL: ts.URL}).Fetch()
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	expect := &Rollout{
+		Version:    "3.0.0",
+		Percentage: 10,
+		Start:      time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC),
+		End:        time.Date(2015, 6, 8, 0, 0, 0, 0, time.UTC),
+	}
+
+	if got := fr.Releases[0].Rollout; !reflect.DeepEqual(got, expect) {
+		t.Fatalf("expects %#v to be %#v", got, expect)
+	}
+}
+
+func TestCheck_rollout(t *testing.T) {
+	start := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return now }
+
+	// Find installs inside and outside of the first 10%
+	var in, out string
+	for i := 0; in == "" || out == ""; i++ {
+		id := fmt.Sprintf("install-%d", i)
+		if rolloutBucket(id, "3.0.0") < 10 {
+			in = id
+		} else if rolloutBucket(id, "3.0.0") >= 50 {
+			out = id
+		}
+	}
+
+	tests := []struct {
+		policy        *RolloutPolicy
+		now           time.Time
+		expectOutdate bool
+		expectPending bool
+	}{
+		{policy: nil, now: now, expectOutdate: true},
+		{policy: &RolloutPolicy{InstallID: in}, now: now, expectOutdate: true},
+		{policy: &RolloutPolicy{InstallID: out}, now: now, expectPending: true},
+		{policy: &RolloutPolicy{InstallID: out}, now: now.AddDate(0, 0, 7), expectOutdate: true},
+		{
+			// Schedule in config is preferred
+			policy: &RolloutPolicy{
+				InstallID: in,
+				Schedule:  []*Rollout{{Version: "3.0.0", Percentage: 0}},
+			},
+			now:           now,
+			expectPending: true,
+		},
+		{
+			// Schedule is matched by parsed version
+			policy: &RolloutPolicy{
+				InstallID: in,
+				Schedule:  []*Rollout{{Version: "v3.0.0", Percentage: 0}},
+			},
+			now:           now,
+			expectPending: true,
+		},
+	}
+
+	s := &fakeSource{
+		versions: []string{"2.0.0", "3.0.0"},
+		releases: []*Release{
+			{Version: "2.0.0"},
+			{Version: "3.0.0", Rollout: &Rollout{Percentage: 10, Start: now, End: now.AddDate(0, 0, 7)}},
+		},
+	}
+	for i, tt := range tests {
+		now = tt.now
+		c := &Checker{Source: s, Rollout: tt.policy}
+		res, err := c.Check("2.0.0")
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Outdated != tt.expectOutdate || res.RolloutPending != tt.expectPending {
+			t.Fatalf("#%d expects outdated, pending (%t, %t) to be (%t, %t)", i,
+				res.Outdated, res.RolloutPending, tt.expectOutdate, tt.expectPending)
+		}
+	}
+	// The only version on source is held back
+	ts := fakeServer("test-fixtures/rollout.json")
+	defer ts.Close()
+
+	now = start
+	c := &Checker{Source: &JSON{URL: ts.URL}, Rollout: &RolloutPolicy{InstallID: out}, SoftFail: true}
+	res, err := c.Check("2.0.0")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	if res.Status != StatusChecked || !res.RolloutPending || !res.Latest || res.Outdated || res.Current != "" {
+		t.Fatalf("expects 2.0.0 to be latest while 3.0.0 is pending: %#v", res)
+	}
+}
+
+func TestLoadInstallID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-latest")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "tool", "install_id")
+	id, err := LoadInstallID(path)
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+	if len(id) != 32 {
+		t.Fatalf("expects %q to be 32 hex characters", id)
+	}
+
+	again, err := LoadInstallID(path)
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+	if again != id {
+		t.Fatalf("expects %q to be persisted %q", again, id)
+	}
+}
@@ -0,0 +1,207 @@
+package latest
+
+import (
+	"github.com/hashicorp/go-version"
+)
+
+// VersionScheme is the interface to parse version strings so that they
+// can be compared. By default, Checker uses HashicorpVersion. To compare
+// versions which don't follow it, set Scheme of Checker to SemVer, CalVer,
+// PEP440, Debian or your own implementation.
+type VersionScheme interface {
+	// Parse parses version string. It returns error if s doesn't
+	// follow the scheme.
+	Parse(s string) (SchemeVersion, error)
+}
+
+// SchemeVersion is version parsed by VersionScheme.
+type SchemeVersion interface {
+	// Compare returns -1, 0 or 1 when the version is less than, equal to or
+	// greater than o. o is always parsed by the same VersionScheme.
+	Compare(o SchemeVersion) int
+
+	// Prerelease returns true if the version is pre-release
+	// (e.g., `1.0.0-beta`).
+	Prerelease() bool
+
+	// String returns version string.
+	String() string
+}
+
+// HashicorpVersion is the default VersionScheme which parses version by
+// hashicorp/go-version. It accepts SemVer-like versions loosely
+// (e.g., `v1.2`, `1.2.3.4`).
+type HashicorpVersion struct{}
+
+func (HashicorpVersion) Parse(s string) (SchemeVersion, error) {
+	v, err := version.NewVersion(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashicorpVersion{v}, nil
+}
+
+type hashicorpVersion struct {
+	v *version.Version
+}
+
+func (v hashicorpVersion) Compare(o SchemeVersion) int {
+	return v.v.Compare(o.(hashicorpVersion).v)
+}
+
+func (v hashicorpVersion) Prerelease() bool {
+	return v.v.Prerelease() != ""
+}
+
+func (v hashicorpVersion) String() string {
+	return v.v.String()
+}
+
+// schemeCandidate is version parsed by VersionScheme with string which
+// it's parsed from.
+type schemeCandidate struct {
+	SchemeVersion
+	raw string
+}
+
+// schemeVersions parses version strings in FetchResponse by scheme and
+// returns parsed versions and strings which can not be parsed.
+//
+// Versions parsed by hashicorp/go-version are used as is for the default
+// scheme. Otherwise original strings of Versions and Malformeds (except
+// Filtered) are parsed again by scheme.
+func schemeVersions(scheme VersionScheme, fr *FetchResponse) ([]schemeCandidate, []string) {
+	var versions []schemeCandidate
+	if _, ok := scheme.(HashicorpVersion); ok {
+		for _, v := range fr.Versions {
+			versions = append(versions, schemeCandidate{hashicorpVersion{v}, v.Original()})
+		}
+		return versions, fr.Malformeds
+	}
+
+	var malformeds []string
+	candidates := make([]string, 0, len(fr.Versions)+len(fr.Malformeds))
+	for _, v := range fr.Versions {
+		candidates = append(candidates, v.Original())
+	}
+	for _, m := range fr.Malformeds {
+		if containsString(fr.Filtered, m) {
+			malformeds = append(malformeds, m)
+			continue
+		}
+		candidates = append(candidates, m)
+	}
+
+	for _, s := range candidates {
+		v, err := scheme.Parse(s)
+		if err != nil {
+			malformeds = append(malformeds, s)
+			continue
+		}
+		versions = append(versions, schemeCandidate{v, s})
+	}
+
+	return versions, malformeds
+}
+
+// stableVersions returns versions which are not pre-release.
+func stableVersions(versions []schemeCandidate) []schemeCandidate {
+	var stables []schemeCandidate
+	for _, v := range versions {
+		if !v.Prerelease() {
+			stables = append(stables, v)
+		}
+	}
+	return stables
+}
+
+// compareIdentifiers compares dot separated identifiers by the rule of
+// SemVer pre-release: numeric identifiers are compared numerically and
+// lower than alphanumeric ones, alphanumeric identifiers are compared
+// lexically, and shorter one is lower when all preceding are equal.
+func compareIdentifiers(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, aNum := parseNumeric(a[i])
+		bn, bNum := parseNumeric(b[i])
+
+		switch {
+		case aNum && bNum:
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if a[i] != b[i] {
+				if a[i] < b[i] {
+					return -1
+				}
+				return 1
+			}
+		}
+	}
+
+	return compareInt(len(a), len(b))
+}
+
+// parseNumeric parses s as non-negative integer. It returns false if s
+// includes non-digit charactor or overflows.
+func parseNumeric(s string) (uint64, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+
+	var n uint64
+	for _, c := range []byte(s) {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		d := uint64(c - '0')
+		if n > (1<<64-1-d)/10 {
+			return 0, false
+		}
+		n = n*10 + d
+	}
+	return n, true
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareSegments compares numeric segments. Missing segments are
+// regarded as 0 (e.g., `1.0` equals `1.0.0`).
+func compareSegments(a, b []uint64) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := compareUint(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
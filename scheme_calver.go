@@ -0,0 +1,97 @@
+package latest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var calverRegexp = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)(?:[-_.]?([0-9a-zA-Z]+(?:[.-][0-9a-zA-Z]+)*))?$`)
+
+// CalVer is VersionScheme for Calendar Versioning (https://calver.org/),
+// e.g., `2024.10.1`, `24.04` or `2024.10.1-beta.1`. Each dot separated
+// segment is compared numerically, so it can be used for any number of
+// numeric segments (e.g., four-part `1.2.3.4`). Missing segments are
+// regarded as 0.
+//
+// Version with modifier (e.g., `-beta`, `rc1`) is pre-release and lower
+// than version without it.
+type CalVer struct{}
+
+func (CalVer) Parse(s string) (SchemeVersion, error) {
+	m := calverRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("%s is not CalVer", s)
+	}
+
+	v := &calverVersion{original: s}
+	for _, seg := range strings.Split(m[1], ".") {
+		n, ok := parseNumeric(seg)
+		if !ok {
+			return nil, fmt.Errorf("%s is not CalVer: too large number", s)
+		}
+		v.segments = append(v.segments, n)
+	}
+
+	if m[2] != "" {
+		v.modifier = splitIdentifiers(strings.ToLower(m[2]))
+	}
+
+	return v, nil
+}
+
+type calverVersion struct {
+	segments []uint64
+	modifier []string
+	original string
+}
+
+func (v *calverVersion) Compare(o SchemeVersion) int {
+	ov := o.(*calverVersion)
+
+	if c := compareSegments(v.segments, ov.segments); c != 0 {
+		return c
+	}
+
+	switch {
+	case len(v.modifier) == 0 && len(ov.modifier) == 0:
+		return 0
+	case len(v.modifier) == 0:
+		return 1
+	case len(ov.modifier) == 0:
+		return -1
+	}
+
+	return compareIdentifiers(v.modifier, ov.modifier)
+}
+
+func (v *calverVersion) Prerelease() bool {
+	return len(v.modifier) != 0
+}
+
+func (v *calverVersion) String() string {
+	return v.original
+}
+
+// splitIdentifiers splits s into identifiers by `.`, `-` and boundary
+// between letters and digits (e.g., `rc.10` and `rc10` become [rc 10]).
+func splitIdentifiers(s string) []string {
+	var ids []string
+	start := 0
+	for i := 1; i <= len(s); i++ {
+		if i < len(s) && s[i] != '.' && s[i] != '-' && s[i-1] != '.' && s[i-1] != '-' &&
+			isDigit(s[i]) == isDigit(s[i-1]) {
+			continue
+		}
+
+		if id := strings.Trim(s[start:i], ".-"); id != "" {
+			ids = append(ids, id)
+		}
+		start = i
+	}
+	return ids
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
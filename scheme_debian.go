@@ -0,0 +1,138 @@
+package latest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Debian is VersionScheme for Debian package versions
+// ([epoch:]upstream_version[-debian_revision]), e.g., `1:2.30-1ubuntu2`
+// or `1.0~rc1-1`. Versions are compared by the algorithm of dpkg, so
+// `~` sorts before anything (even the end of version). Version whose
+// upstream_version includes `~` is pre-release.
+type Debian struct{}
+
+func (Debian) Parse(s string) (SchemeVersion, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return nil, fmt.Errorf("empty Debian version")
+	}
+
+	v := &debianVersion{original: s}
+
+	if i := strings.Index(str, ":"); i >= 0 {
+		epoch, ok := parseNumeric(str[:i])
+		if !ok {
+			return nil, fmt.Errorf("%s is not Debian version: invalid epoch", s)
+		}
+		v.epoch, str = epoch, str[i+1:]
+	}
+
+	v.upstream = str
+	if i := strings.LastIndex(str, "-"); i >= 0 {
+		v.upstream, v.revision = str[:i], str[i+1:]
+		if v.revision == "" || !validDebianPart(v.revision, "+.~") {
+			return nil, fmt.Errorf("%s is not Debian version: invalid revision", s)
+		}
+	}
+
+	if v.upstream == "" || !isDigit(v.upstream[0]) || !validDebianPart(v.upstream, "+.~-:") {
+		return nil, fmt.Errorf("%s is not Debian version: invalid upstream version", s)
+	}
+
+	return v, nil
+}
+
+// validDebianPart checks s includes only alphanumerics and allowed symbols.
+func validDebianPart(s, allowed string) bool {
+	for _, c := range []byte(s) {
+		if isDigit(c) || isLetter(c) || strings.IndexByte(allowed, c) >= 0 {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+type debianVersion struct {
+	epoch    uint64
+	upstream string
+	revision string
+	original string
+}
+
+func (v *debianVersion) Compare(o SchemeVersion) int {
+	ov := o.(*debianVersion)
+
+	if c := compareUint(v.epoch, ov.epoch); c != 0 {
+		return c
+	}
+
+	if c := debianCompare(v.upstream, ov.upstream); c != 0 {
+		return c
+	}
+
+	return debianCompare(v.revision, ov.revision)
+}
+
+func (v *debianVersion) Prerelease() bool {
+	return strings.Contains(v.upstream, "~")
+}
+
+func (v *debianVersion) String() string {
+	return v.original
+}
+
+// debianCompare compares a part of Debian version in the same way as
+// verrevcmp of dpkg. Non-digit and digit parts are compared in turn.
+func debianCompare(a, b string) int {
+	for len(a) > 0 || len(b) > 0 {
+		// Compare non-digit parts charactor by charactor
+		for (len(a) > 0 && !isDigit(a[0])) || (len(b) > 0 && !isDigit(b[0])) {
+			ac, bc := debianOrder(a), debianOrder(b)
+			if ac != bc {
+				return compareInt(ac, bc)
+			}
+			a, b = a[1:], b[1:]
+		}
+
+		// Compare digit parts numerically
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		firstDiff := 0
+		for len(a) > 0 && isDigit(a[0]) && len(b) > 0 && isDigit(b[0]) {
+			if firstDiff == 0 {
+				firstDiff = compareInt(int(a[0]), int(b[0]))
+			}
+			a, b = a[1:], b[1:]
+		}
+		if len(a) > 0 && isDigit(a[0]) {
+			return 1
+		}
+		if len(b) > 0 && isDigit(b[0]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+
+	return 0
+}
+
+// debianOrder returns weight of the first charactor of s. `~` is lower
+// than the end of string, and letters are lower than other symbols.
+func debianOrder(s string) int {
+	switch {
+	case len(s) == 0 || isDigit(s[0]):
+		return 0
+	case isLetter(s[0]):
+		return int(s[0])
+	case s[0] == '~':
+		return -1
+	}
+	return int(s[0]) + 256
+}
+
+func isLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
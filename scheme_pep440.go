@@ -0,0 +1,217 @@
+package latest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pep440Regexp is the regular expression of PEP 440 including
+// permitted variations (https://peps.python.org/pep-0440/#normalization).
+var pep440Regexp = regexp.MustCompile(`(?i)^v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|beta|preview|pre|rc|a|b|c)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// PEP440 is VersionScheme for Python package versions defined by PEP 440
+// (https://peps.python.org/pep-0440/), e.g., `1.0a1`, `1.0.post1`,
+// `1.0.dev2` or `1!2.0`. Permitted variations like `1.0-alpha.1` are
+// normalized before comparing. Alpha, beta, release candidate and
+// development releases are pre-release.
+type PEP440 struct{}
+
+func (PEP440) Parse(s string) (SchemeVersion, error) {
+	m := pep440Regexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil, fmt.Errorf("%s is not PEP 440 version", s)
+	}
+
+	group := func(name string) string {
+		return m[pep440Regexp.SubexpIndex(name)]
+	}
+
+	number := func(str string) (uint64, error) {
+		if str == "" {
+			return 0, nil
+		}
+		n, ok := parseNumeric(str)
+		if !ok {
+			return 0, fmt.Errorf("%s is not PEP 440 version: too large number", s)
+		}
+		return n, nil
+	}
+
+	v := &pep440Version{original: s}
+
+	var err error
+	if v.epoch, err = number(group("epoch")); err != nil {
+		return nil, err
+	}
+
+	for _, seg := range strings.Split(group("release"), ".") {
+		n, err := number(seg)
+		if err != nil {
+			return nil, err
+		}
+		v.release = append(v.release, n)
+	}
+
+	if l := strings.ToLower(group("pre_l")); l != "" {
+		v.hasPre = true
+		switch l {
+		case "a", "alpha":
+			v.preKind = 0
+		case "b", "beta":
+			v.preKind = 1
+		default:
+			// c, pre, preview and rc
+			v.preKind = 2
+		}
+		if v.preN, err = number(group("pre_n")); err != nil {
+			return nil, err
+		}
+	}
+
+	if n := group("post_n1"); n != "" {
+		v.hasPost = true
+		if v.postN, err = number(n); err != nil {
+			return nil, err
+		}
+	} else if group("post_l") != "" {
+		v.hasPost = true
+		if v.postN, err = number(group("post_n2")); err != nil {
+			return nil, err
+		}
+	}
+
+	if group("dev_l") != "" {
+		v.hasDev = true
+		if v.devN, err = number(group("dev_n")); err != nil {
+			return nil, err
+		}
+	}
+
+	if l := group("local"); l != "" {
+		v.local = strings.FieldsFunc(strings.ToLower(l), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+
+	return v, nil
+}
+
+type pep440Version struct {
+	epoch   uint64
+	release []uint64
+
+	hasPre  bool
+	preKind int // 0: alpha, 1: beta, 2: release candidate
+	preN    uint64
+
+	hasPost bool
+	postN   uint64
+
+	hasDev bool
+	devN   uint64
+
+	local    []string
+	original string
+}
+
+// preKey returns sort key of pre-release segment. Development release
+// without pre and post-release (e.g., `1.0.dev1`) is lower than any
+// pre-release, and final release is greater than pre-release.
+func (v *pep440Version) preKey() (rank int, kind int, n uint64) {
+	switch {
+	case !v.hasPre && !v.hasPost && v.hasDev:
+		return 0, 0, 0
+	case !v.hasPre:
+		return 2, 0, 0
+	}
+	return 1, v.preKind, v.preN
+}
+
+func (v *pep440Version) Compare(o SchemeVersion) int {
+	ov := o.(*pep440Version)
+
+	if c := compareUint(v.epoch, ov.epoch); c != 0 {
+		return c
+	}
+
+	if c := compareSegments(v.release, ov.release); c != 0 {
+		return c
+	}
+
+	vr, vk, vn := v.preKey()
+	or, ok, on := ov.preKey()
+	if c := compareInt(vr, or); c != 0 {
+		return c
+	}
+	if c := compareInt(vk, ok); c != 0 {
+		return c
+	}
+	if c := compareUint(vn, on); c != 0 {
+		return c
+	}
+
+	// Version without post-release is lower
+	if v.hasPost != ov.hasPost {
+		if v.hasPost {
+			return 1
+		}
+		return -1
+	}
+	if c := compareUint(v.postN, ov.postN); c != 0 {
+		return c
+	}
+
+	// Version without development release is greater
+	if v.hasDev != ov.hasDev {
+		if v.hasDev {
+			return -1
+		}
+		return 1
+	}
+	if c := compareUint(v.devN, ov.devN); c != 0 {
+		return c
+	}
+
+	return compareLocal(v.local, ov.local)
+}
+
+// compareLocal compares local version labels. Version without label is
+// lower, numeric segments are greater than alphanumeric ones.
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, aNum := parseNumeric(a[i])
+		bn, bNum := parseNumeric(b[i])
+
+		switch {
+		case aNum && bNum:
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aNum:
+			return 1
+		case bNum:
+			return -1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInt(len(a), len(b))
+}
+
+func (v *pep440Version) Prerelease() bool {
+	return v.hasPre || v.hasDev
+}
+
+func (v *pep440Version) String() string {
+	return v.original
+}
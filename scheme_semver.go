@@ -0,0 +1,75 @@
+package latest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// semverRegexp is the regular expression suggested by SemVer 2.0.0
+// (https://semver.org/) with optional `v` prefix.
+var semverRegexp = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// SemVer is VersionScheme which strictly follows Semantic Versioning 2.0.0
+// (https://semver.org/). Version must have exactly MAJOR.MINOR.PATCH
+// (`v` prefix is allowed) and leading zeros are rejected. Build metadata
+// is ignored when comparing.
+type SemVer struct{}
+
+func (SemVer) Parse(s string) (SchemeVersion, error) {
+	m := semverRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("%s is not SemVer 2.0", s)
+	}
+
+	v := &semverVersion{original: s}
+	for i := 0; i < 3; i++ {
+		n, ok := parseNumeric(m[i+1])
+		if !ok {
+			return nil, fmt.Errorf("%s is not SemVer 2.0: too large number", s)
+		}
+		v.core[i] = n
+	}
+
+	if m[4] != "" {
+		v.pre = strings.Split(m[4], ".")
+	}
+
+	return v, nil
+}
+
+type semverVersion struct {
+	core     [3]uint64
+	pre      []string
+	original string
+}
+
+func (v *semverVersion) Compare(o SchemeVersion) int {
+	ov := o.(*semverVersion)
+
+	if c := compareSegments(v.core[:], ov.core[:]); c != 0 {
+		return c
+	}
+
+	// Version without pre-release is greater than with it
+	switch {
+	case len(v.pre) == 0 && len(ov.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(ov.pre) == 0:
+		return -1
+	}
+
+	return compareIdentifiers(v.pre, ov.pre)
+}
+
+func (v *semverVersion) Prerelease() bool {
+	return len(v.pre) != 0
+}
+
+func (v *semverVersion) String() string {
+	return v.original
+}
@@ -0,0 +1,306 @@
+package latest
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestVersionScheme_implement(t *testing.T) {
+	var _ VersionScheme = HashicorpVersion{}
+	var _ VersionScheme = SemVer{}
+	var _ VersionScheme = CalVer{}
+	var _ VersionScheme = PEP440{}
+	var _ VersionScheme = Debian{}
+}
+
+func TestVersionSchemeCompare(t *testing.T) {
+	tests := []struct {
+		scheme VersionScheme
+		a, b   string
+		expect int
+	}{
+		{HashicorpVersion{}, "1.0.0", "1.0.1", -1},
+		{HashicorpVersion{}, "v1.0", "1.0.0", 0},
+
+		{SemVer{}, "1.0.0", "2.0.0", -1},
+		{SemVer{}, "1.10.0", "1.9.0", 1},
+		{SemVer{}, "1.0.0-alpha", "1.0.0", -1},
+		{SemVer{}, "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{SemVer{}, "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{SemVer{}, "1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{SemVer{}, "1.0.0-rc.1", "1.0.0-beta.11", 1},
+		{SemVer{}, "1.0.0+20130313", "1.0.0+exp.sha", 0},
+		{SemVer{}, "v1.2.3", "1.2.3", 0},
+
+		{CalVer{}, "2024.10.1", "2024.9.30", 1},
+		{CalVer{}, "24.04", "24.04.0", 0},
+		{CalVer{}, "2024.10.1-beta", "2024.10.1", -1},
+		{CalVer{}, "2024.10.1rc2", "2024.10.1rc10", -1},
+		{CalVer{}, "1.2.3.10", "1.2.3.9", 1},
+
+		{PEP440{}, "1.0.dev1", "1.0a1", -1},
+		{PEP440{}, "1.0a1", "1.0b1", -1},
+		{PEP440{}, "1.0b2", "1.0rc1", -1},
+		{PEP440{}, "1.0rc1", "1.0", -1},
+		{PEP440{}, "1.0", "1.0.post1", -1},
+		{PEP440{}, "1.0.post1.dev1", "1.0.post1", -1},
+		{PEP440{}, "1.0-alpha.1", "1.0a1", 0},
+		{PEP440{}, "1.0-1", "1.0.post1", 0},
+		{PEP440{}, "1!1.0", "2.0", 1},
+		{PEP440{}, "1.0", "1.0+local.1", -1},
+		{PEP440{}, "1.0+abc", "1.0+1", -1},
+		{PEP440{}, "1.0", "1.0.0", 0},
+
+		{Debian{}, "1.0-1", "1.0-2", -1},
+		{Debian{}, "1.0~rc1-1", "1.0-1", -1},
+		{Debian{}, "1:0.9-1", "2.0-1", 1},
+		{Debian{}, "2.30-1ubuntu2", "2.30-1", 1},
+		{Debian{}, "1.0a", "1.0+", -1},
+		{Debian{}, "1.01", "1.1", 0},
+		{Debian{}, "1.0~~", "1.0~", -1},
+	}
+
+	for i, tt := range tests {
+		a, err := tt.scheme.Parse(tt.a)
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		b, err := tt.scheme.Parse(tt.b)
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if got := a.Compare(b); got != tt.expect {
+			t.Fatalf("#%d expects %s compared with %s to be %d, got %d", i, tt.a, tt.b, tt.expect, got)
+		}
+
+		if got := b.Compare(a); got != -tt.expect {
+			t.Fatalf("#%d expects %s compared with %s to be %d, got %d", i, tt.b, tt.a, -tt.expect, got)
+		}
+	}
+}
+
+func TestVersionSchemeParse(t *testing.T) {
+	tests := []struct {
+		scheme           VersionScheme
+		in               string
+		expectErr        bool
+		expectPrerelease bool
+	}{
+		{SemVer{}, "1.2.3", false, false},
+		{SemVer{}, "1.2.3-rc.1+build", false, true},
+		{SemVer{}, "1.2", true, false},
+		{SemVer{}, "01.2.3", true, false},
+		{SemVer{}, "1.2.3.4", true, false},
+		{SemVer{}, "1.2.3-01", true, false},
+
+		{CalVer{}, "2024.10.1", false, false},
+		{CalVer{}, "2024.10.1-dev", false, true},
+		{CalVer{}, "release-2024", true, false},
+
+		{PEP440{}, "1.0.post1", false, false},
+		{PEP440{}, "1.0.dev1", false, true},
+		{PEP440{}, "1.0RC1", false, true},
+		{PEP440{}, "1.0-foo", true, false},
+
+		{Debian{}, "1:2.30-1ubuntu2", false, false},
+		{Debian{}, "1.0~beta1-1", false, true},
+		{Debian{}, "a1.0", true, false},
+		{Debian{}, "x:1.0", true, false},
+		{Debian{}, "1.0-", true, false},
+	}
+
+	for i, tt := range tests {
+		v, err := tt.scheme.Parse(tt.in)
+		if (err != nil) != tt.expectErr {
+			t.Fatalf("#%d expects err of %s to be %t: %s", i, tt.in, tt.expectErr, err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if v.Prerelease() != tt.expectPrerelease {
+			t.Fatalf("#%d expects %s to be pre-release %t", i, tt.in, tt.expectPrerelease)
+		}
+
+		if v.String() != tt.in {
+			t.Fatalf("#%d expects %q to be %q", i, v.String(), tt.in)
+		}
+	}
+}
+
+func TestChecker_scheme(t *testing.T) {
+	tests := []struct {
+		scheme           VersionScheme
+		ignorePrerelease bool
+		versions         []string
+		malformeds       []string
+		filtered         []string
+		target           string
+		expectCurrent    string
+		expectOutdated   bool
+		expectMalformeds int
+	}{
+		{
+			scheme:         CalVer{},
+			versions:       []string{"2024.9.30", "2024.10.1"},
+			target:         "2024.9.30",
+			expectCurrent:  "2024.10.1",
+			expectOutdated: true,
+		},
+		{
+			scheme:         PEP440{},
+			versions:       []string{"1.0.0", "1.0.0-rc1"},
+			malformeds:     []string{"1.0.post1"},
+			target:         "1.0",
+			expectCurrent:  "1.0.post1",
+			expectOutdated: true,
+		},
+		{
+			scheme:           Debian{},
+			versions:         []string{"1.0.0"},
+			malformeds:       []string{"1:0.9-1", "2:0.1-1"},
+			filtered:         []string{"2:0.1-1"},
+			target:           "1.0.0",
+			expectCurrent:    "1:0.9-1",
+			expectOutdated:   true,
+			expectMalformeds: 1,
+		},
+		{
+			scheme:           SemVer{},
+			ignorePrerelease: true,
+			versions:         []string{"1.0.0", "1.1.0-beta.1", "1.0"},
+			target:           "1.0.0",
+			expectCurrent:    "1.0.0",
+			expectMalformeds: 1,
+		},
+	}
+
+	for i, tt := range tests {
+		s := &fakeSource{versions: tt.versions, malformeds: tt.malformeds, filtered: tt.filtered}
+		c := &Checker{Source: s, Scheme: tt.scheme, IgnorePrerelease: tt.ignorePrerelease}
+		res, err := c.Check(tt.target)
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Current != tt.expectCurrent {
+			t.Fatalf("#%d expects %q to be %q", i, res.Current, tt.expectCurrent)
+		}
+
+		if res.Outdated != tt.expectOutdated {
+			t.Fatalf("#%d expects %t to be %t", i, res.Outdated, tt.expectOutdated)
+		}
+
+		if len(res.Malformeds) != tt.expectMalformeds {
+			t.Fatalf("#%d expects %v to have %d malformeds", i, res.Malformeds, tt.expectMalformeds)
+		}
+	}
+}
+
+func TestChecker_schemeReleaseAdvisory(t *testing.T) {
+	tests := []struct {
+		scheme         VersionScheme
+		versions       []string
+		schedule       string
+		target         string
+		affected       string
+		expectCurrent  string
+		expectPending  bool
+		expectAffected bool
+	}{
+		{
+			scheme:         Debian{},
+			versions:       []string{"1:0.9-1", "1:1.0-1"},
+			schedule:       "1:1.00-1",
+			target:         "1:0.9-1",
+			affected:       ">= 1:0.9-1, < 1:1.0-1",
+			expectCurrent:  "1:0.9-1",
+			expectPending:  true,
+			expectAffected: true,
+		},
+		{
+			scheme:         PEP440{},
+			versions:       []string{"1.0rc1", "1.0"},
+			schedule:       "1.0.0",
+			target:         "1.0rc1",
+			affected:       ">= 1.0a1, < 1.0",
+			expectCurrent:  "1.0rc1",
+			expectPending:  true,
+			expectAffected: true,
+		},
+		{
+			scheme:         CalVer{},
+			versions:       []string{"2024.9.1", "2024.10.1"},
+			schedule:       "2024.10.01",
+			target:         "2024.9.1",
+			affected:       ">= 2024.1.0, < 2024.10.0",
+			expectCurrent:  "2024.9.1",
+			expectPending:  true,
+			expectAffected: true,
+		},
+		{
+			scheme:         CalVer{},
+			versions:       []string{"2024.9.1", "2024.10.1"},
+			target:         "2024.10.1",
+			affected:       ">= 2024.1.0, < 2024.10.0",
+			expectCurrent:  "2024.10.1",
+			expectAffected: false,
+		},
+	}
+
+	for i, tt := range tests {
+		s := &fakeSource{advisories: []*Advisory{{ID: "CVE-2015-0001", Affected: tt.affected}}}
+		for _, v := range tt.versions {
+			if _, err := version.NewVersion(v); err != nil {
+				s.malformeds = append(s.malformeds, v)
+			} else {
+				s.versions = append(s.versions, v)
+			}
+			s.releases = append(s.releases, &Release{Version: v})
+		}
+
+		// Schedule is written in a different form of the greatest version
+		var policy *RolloutPolicy
+		if tt.schedule != "" {
+			policy = &RolloutPolicy{InstallID: "install", Schedule: []*Rollout{{Version: tt.schedule, Percentage: 0}}}
+		}
+
+		c := &Checker{Source: s, Scheme: tt.scheme, Rollout: policy}
+		res, err := c.Check(tt.target)
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Current != tt.expectCurrent || res.RolloutPending != tt.expectPending {
+			t.Fatalf("#%d expects current, pending (%q, %t) to be (%q, %t)", i,
+				res.Current, res.RolloutPending, tt.expectCurrent, tt.expectPending)
+		}
+
+		if res.Vulnerable != tt.expectAffected {
+			t.Fatalf("#%d expects vulnerable %t to be %t", i, res.Vulnerable, tt.expectAffected)
+		}
+	}
+}
+
+func TestFindRelease_scheme(t *testing.T) {
+	tests := []struct {
+		scheme  VersionScheme
+		release string
+		lookup  string
+	}{
+		{scheme: Debian{}, release: "1.0-1", lookup: "0:1.0-1"},
+		{scheme: PEP440{}, release: "1.0", lookup: "1.0.0"},
+		{scheme: CalVer{}, release: "2024.1.5", lookup: "2024.01.05"},
+	}
+
+	for i, tt := range tests {
+		releases := []*Release{{Version: "0.0.1"}, {Version: tt.release}}
+		if r := findRelease(releases, tt.scheme, tt.lookup); r == nil || r.Version != tt.release {
+			t.Fatalf("#%d expects %#v to be %s", i, r, tt.release)
+		}
+	}
+}
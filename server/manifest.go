@@ -0,0 +1,206 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/tcnksm/go-latest"
+)
+
+// DefaultChannel is release channel used when client doesn't specify it.
+const DefaultChannel = "stable"
+
+// Manifest describes releases of products which Handler serves.
+// It is usually written in JSON file like below,
+//
+//	{
+//	  "products": {
+//	    "reduce-worker": {
+//	      "channels": {
+//	        "stable": {"version": "1.2.3", "message": "Security fix", "url": "https://example.com/1.2.3"},
+//	        "beta":   {"version": "1.3.0-beta1"}
+//	      }
+//	    }
+//	  }
+//	}
+type Manifest struct {
+	Products map[string]*Product `json:"products"`
+}
+
+// Product is releases of a single product per channel.
+//
+// Rules are used to tailor response by client information (see Rule).
+// The first rule which matches is used instead of the channel's release.
+//
+//	"rules": [
+//	  {"version": ">= 1.2, < 1.2.5", "release": {"version": "1.2.5", "message": "1.2.x has a CVE, upgrade now"}}
+//	]
+type Product struct {
+	Channels map[string]*Release `json:"channels"`
+	Rules    []*ReleaseRule      `json:"rules,omitempty"`
+
+	// Advisories are security advisories of the product. They are
+	// included in JSON response regardless of channel.
+	Advisories []*latest.Advisory `json:"advisories,omitempty"`
+
+	// Retractions are retracted or deprecated versions of the product.
+	// They are never recommended as current version by clients.
+	Retractions []*latest.Retraction `json:"retractions,omitempty"`
+}
+
+// ReleaseRule is Release returned to clients which match Rule.
+type ReleaseRule struct {
+	Rule
+	Release *Release `json:"release"`
+}
+
+// Release is a single release advertised to clients.
+type Release struct {
+	Version    string `json:"version"`
+	Message    string `json:"message,omitempty"`
+	URL        string `json:"url,omitempty"`
+	MinVersion string `json:"min_version,omitempty"`
+
+	// EOL is end-of-life date of versions less than MinVersion
+	// (`2006-01-02` or RFC3339 format).
+	EOL string `json:"eol,omitempty"`
+
+	// Rollout is staged rollout of the release. Clients which use
+	// latest.RolloutPolicy are told only when they are selected.
+	Rollout *latest.Rollout `json:"rollout,omitempty"`
+}
+
+// LoadManifest reads manifest from JSON file and validates it.
+func LoadManifest(path string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var m Manifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %s: %s", path, err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Validate checks every release has valid version.
+func (m *Manifest) Validate() error {
+	if len(m.Products) == 0 {
+		return fmt.Errorf("manifest must have at least one product")
+	}
+
+	for name, p := range m.Products {
+		if p == nil || len(p.Channels) == 0 {
+			return fmt.Errorf("product %s must have at least one channel", name)
+		}
+
+		for channel, r := range p.Channels {
+			if err := r.validate(); err != nil {
+				return fmt.Errorf("release of %s (%s) %s", name, channel, err)
+			}
+		}
+
+		for i, r := range p.Rules {
+			if r == nil {
+				return fmt.Errorf("rule #%d of %s must be set", i, name)
+			}
+
+			if err := r.Rule.Validate(); err != nil {
+				return fmt.Errorf("rule #%d of %s has %s", i, name, err)
+			}
+
+			if err := r.Release.validate(); err != nil {
+				return fmt.Errorf("release of rule #%d of %s %s", i, name, err)
+			}
+		}
+
+		for _, a := range p.Advisories {
+			if _, err := version.NewConstraint(a.Affected); err != nil {
+				return fmt.Errorf("advisory %s of %s has invalid affected range %q: %s", a.ID, name, a.Affected, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Release) validate() error {
+	if r == nil {
+		return fmt.Errorf("must be set")
+	}
+
+	if _, err := version.NewVersion(r.Version); err != nil {
+		return fmt.Errorf("has invalid version %q: %s", r.Version, err)
+	}
+
+	if r.MinVersion != "" {
+		if _, err := version.NewVersion(r.MinVersion); err != nil {
+			return fmt.Errorf("has invalid min_version %q: %s", r.MinVersion, err)
+		}
+	}
+
+	if r.EOL != "" {
+		if _, err := time.Parse("2006-01-02", r.EOL); err != nil {
+			if _, err := time.Parse(time.RFC3339, r.EOL); err != nil {
+				return fmt.Errorf("has invalid eol %q", r.EOL)
+			}
+		}
+	}
+
+	return nil
+}
+
+// release returns release of the channel for client. If one of rules
+// matches, its release is returned.
+func (p *Product) release(channel string, c *latest.ClientInfo) (*Release, bool) {
+	cc := *c
+	cc.Channel = channel
+
+	for _, r := range p.Rules {
+		if r.Match(&cc) {
+			return r.Release, true
+		}
+	}
+
+	release, ok := p.Channels[channel]
+	return release, ok
+}
+
+// productNames returns product names in sorted order.
+func (m *Manifest) productNames() []string {
+	names := make([]string, 0, len(m.Products))
+	for name := range m.Products {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// channelNames returns channel names. DefaultChannel comes first and
+// others follow in sorted order, because clients before channel support
+// use the first meta tag of the product.
+func (p *Product) channelNames() []string {
+	names := make([]string, 0, len(p.Channels))
+	for name := range p.Channels {
+		if name != DefaultChannel {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if _, ok := p.Channels[DefaultChannel]; ok {
+		names = append([]string{DefaultChannel}, names...)
+	}
+	return names
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-version"
+	"github.com/tcnksm/go-latest"
+)
+
+// Rule is condition on client information which go-latest sources send
+// (see latest.ClientInfo). Empty field matches anything. Rule with Version
+// never matches client which doesn't send its version, so plain GET request
+// from static client falls back to default response.
+type Rule struct {
+	// Version is version constraint by hashicorp/go-version.
+	// e.g., `>= 1.2, < 1.3`
+	Version string `json:"version,omitempty"`
+
+	// OS and Arch are platform of client. e.g., `linux` and `amd64`.
+	OS   string `json:"os,omitempty"`
+	Arch string `json:"arch,omitempty"`
+
+	// Channel is release channel of client. Client which doesn't
+	// send channel is regarded as DefaultChannel.
+	Channel string `json:"channel,omitempty"`
+}
+
+// Validate checks Version is valid constraint.
+func (r *Rule) Validate() error {
+	if r.Version == "" {
+		return nil
+	}
+
+	if _, err := version.NewConstraint(r.Version); err != nil {
+		return fmt.Errorf("invalid version constraint %q: %s", r.Version, err)
+	}
+
+	return nil
+}
+
+// Match returns true when client satisfies all conditions.
+func (r *Rule) Match(c *latest.ClientInfo) bool {
+	if r.OS != "" && r.OS != c.OS {
+		return false
+	}
+
+	if r.Arch != "" && r.Arch != c.Arch {
+		return false
+	}
+
+	if r.Channel != "" && r.Channel != clientChannel(c) {
+		return false
+	}
+
+	if r.Version != "" {
+		constraints, err := version.NewConstraint(r.Version)
+		if err != nil {
+			return false
+		}
+
+		v, err := version.NewVersion(c.Version)
+		if err != nil {
+			return false
+		}
+
+		if !constraints.Check(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func clientChannel(c *latest.ClientInfo) string {
+	if c.Channel == "" {
+		return DefaultChannel
+	}
+
+	return c.Channel
+}
+
+// Route is a pair of Rule and Handler which serves matched request.
+type Route struct {
+	Rule    *Rule
+	Handler http.Handler
+}
+
+// Router is http.Handler which picks response by client information sent
+// as query parameters. It dispatches request to Handler of the first Route
+// whose Rule matches. If nothing matches, Default is used. It can be used
+// to tailor any endpoint, e.g., static files,
+//
+//	router := &server.Router{
+//	    Routes: []*server.Route{
+//	        {Rule: &server.Rule{Version: "< 1.3"}, Handler: cveHandler},
+//	    },
+//	    Default: http.FileServer(http.Dir("public")),
+//	}
+type Router struct {
+	Routes  []*Route
+	Default http.Handler
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c := latest.ClientInfoFromQuery(r.URL.Query())
+	for _, route := range rt.Routes {
+		if route.Rule.Match(c) {
+			route.Handler.ServeHTTP(w, r)
+			return
+		}
+	}
+
+	if rt.Default == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	rt.Default.ServeHTTP(w, r)
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tcnksm/go-latest"
+)
+
+func TestRuleMatch(t *testing.T) {
+	tests := []struct {
+		rule   *Rule
+		client *latest.ClientInfo
+		expect bool
+	}{
+		{rule: &Rule{}, client: &latest.ClientInfo{}, expect: true},
+		{rule: &Rule{Version: "< 1.3"}, client: &latest.ClientInfo{Version: "1.2.9"}, expect: true},
+		{rule: &Rule{Version: "< 1.3"}, client: &latest.ClientInfo{Version: "1.3.0"}, expect: false},
+		{rule: &Rule{Version: "< 1.3"}, client: &latest.ClientInfo{}, expect: false},
+		{rule: &Rule{OS: "windows"}, client: &latest.ClientInfo{OS: "windows", Arch: "386"}, expect: true},
+		{rule: &Rule{OS: "windows", Arch: "amd64"}, client: &latest.ClientInfo{OS: "windows", Arch: "386"}, expect: false},
+		{rule: &Rule{Channel: "stable"}, client: &latest.ClientInfo{}, expect: true},
+		{rule: &Rule{Channel: "beta"}, client: &latest.ClientInfo{}, expect: false},
+		{rule: &Rule{Channel: "beta"}, client: &latest.ClientInfo{Channel: "beta"}, expect: true},
+	}
+
+	for i, tt := range tests {
+		if got := tt.rule.Match(tt.client); got != tt.expect {
+			t.Fatalf("#%d Match() expects %t to be %t", i, got, tt.expect)
+		}
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	if err := (&Rule{Version: ">= 1.0, < 2.0"}).Validate(); err != nil {
+		t.Fatalf("Validate() expects error:%q to be nil", err.Error())
+	}
+
+	if err := (&Rule{Version: "~> one"}).Validate(); err == nil {
+		t.Fatalf("Validate() expects error")
+	}
+}
+
+func TestRouter(t *testing.T) {
+	text := func(s string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, s)
+		})
+	}
+
+	router := &Router{
+		Routes: []*Route{
+			{Rule: &Rule{Version: "< 1.0"}, Handler: text("unsupported")},
+			{Rule: &Rule{OS: "windows"}, Handler: text("windows")},
+		},
+		Default: text("default"),
+	}
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	tests := []struct {
+		query  string
+		expect string
+	}{
+		{query: "", expect: "default"},
+		{query: "?version=0.9.0&os=windows", expect: "unsupported"},
+		{query: "?version=1.0.0&os=windows", expect: "windows"},
+		{query: "?version=1.0.0&os=linux", expect: "default"},
+	}
+
+	for i, tt := range tests {
+		resp, err := http.Get(ts.URL + tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if string(b) != tt.expect {
+			t.Fatalf("#%d expects %q to be %q", i, string(b), tt.expect)
+		}
+	}
+}
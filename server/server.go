@@ -0,0 +1,222 @@
+/*
+Package server provides http.Handler which hosts go-latest endpoints.
+It serves version information for many products from a single Manifest
+in formats which go-latest sources understand.
+
+	GET /                         HTML meta tags of all products (latest.HTMLMeta)
+	GET /PRODUCT or /PRODUCT.html HTML meta tags of PRODUCT (latest.HTMLMeta)
+	GET /PRODUCT.json             JSON of PRODUCT (latest.JSON)
+
+JSON endpoint returns `stable` channel by default. Use `channel` query
+parameter to get other channels (e.g., /reduce-worker.json?channel=beta).
+HTML endpoints include every channel with data-channel attribute.
+
+Response is tailored by client information (latest.ClientInfo) sent as
+query parameters with Rules in Manifest. Plain GET request gets default
+response. Router can be used to tailor any other endpoints.
+
+	m, _ := server.LoadManifest("manifest.json")
+	http.ListenAndServe(":8080", server.New(m))
+*/
+package server
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tcnksm/go-latest"
+)
+
+// DefaultMaxAge is default value of Cache-Control max-age.
+const DefaultMaxAge = 5 * time.Minute
+
+// Handler is http.Handler which serves version information in Manifest.
+type Handler struct {
+	// Manifest is releases to serve.
+	Manifest *Manifest
+
+	// MaxAge is used for Cache-Control header. By default,
+	// DefaultMaxAge is used.
+	MaxAge time.Duration
+}
+
+// New returns Handler which serves m.
+func New(m *Manifest) *Handler {
+	return &Handler{
+		Manifest: m,
+		MaxAge:   DefaultMaxAge,
+	}
+}
+
+func (h *Handler) maxAge() time.Duration {
+	if h.MaxAge == 0 {
+		return DefaultMaxAge
+	}
+
+	return h.MaxAge
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.Trim(r.URL.Path, "/")
+	if name == "" {
+		h.serveHTML(w, r, h.Manifest.productNames())
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(name, ".json"):
+		h.serveJSON(w, r, strings.TrimSuffix(name, ".json"))
+	default:
+		name = strings.TrimSuffix(name, ".html")
+		if _, ok := h.Manifest.Products[name]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		h.serveHTML(w, r, []string{name})
+	}
+}
+
+// jsonResponse is the format which latest.JSON decodes by default.
+type jsonResponse struct {
+	Version    string `json:"version"`
+	Message    string `json:"message,omitempty"`
+	URL        string `json:"url,omitempty"`
+	MinVersion string `json:"min_version,omitempty"`
+	EOL        string `json:"eol,omitempty"`
+
+	Rollout     *latest.Rollout      `json:"rollout,omitempty"`
+	Advisories  []*latest.Advisory   `json:"advisories,omitempty"`
+	Retractions []*latest.Retraction `json:"retractions,omitempty"`
+}
+
+func (h *Handler) serveJSON(w http.ResponseWriter, r *http.Request, name string) {
+	p, ok := h.Manifest.Products[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	c := latest.ClientInfoFromQuery(r.URL.Query())
+	channel := clientChannel(c)
+
+	release, ok := p.release(channel, c)
+	if !ok {
+		http.Error(w, fmt.Sprintf("channel %s is not found for %s", channel, name), http.StatusNotFound)
+		return
+	}
+
+	body, err := json.Marshal(&jsonResponse{
+		Version:     release.Version,
+		Message:     release.Message,
+		URL:         release.URL,
+		MinVersion:  release.MinVersion,
+		EOL:         release.EOL,
+		Rollout:     release.Rollout,
+		Advisories:  p.Advisories,
+		Retractions: p.Retractions,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.write(w, r, "application/json", body)
+}
+
+var htmlTemplate = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html>
+  <head>
+    <title>go-latest</title>
+{{- range .}}
+    <meta name="{{.Name}}" content="{{.Content}}" data-channel="{{.Channel}}"
+    {{- with .URL}} data-url="{{.}}"{{end}}
+    {{- with .MinVersion}} data-min-version="{{.}}"{{end}}
+    {{- with .EOL}} data-eol="{{.}}"{{end}}>
+{{- end}}
+  </head>
+</html>
+`))
+
+// metaTag is a single go-latest meta tag. See doc/html_meta.md.
+type metaTag struct {
+	Name       string
+	Content    string
+	Channel    string
+	URL        string
+	MinVersion string
+	EOL        string
+}
+
+func (h *Handler) serveHTML(w http.ResponseWriter, r *http.Request, names []string) {
+	// If client sends its channel, only the channel is included.
+	c := latest.ClientInfoFromQuery(r.URL.Query())
+
+	var tags []*metaTag
+	for _, name := range names {
+		p := h.Manifest.Products[name]
+		for _, channel := range p.channelNames() {
+			if c.Channel != "" && c.Channel != channel {
+				continue
+			}
+
+			release, _ := p.release(channel, c)
+			content := strings.TrimSpace(fmt.Sprintf("%s %s %s", name, release.Version, release.Message))
+			tags = append(tags, &metaTag{
+				Name:       latest.MetaTagName,
+				Content:    content,
+				Channel:    channel,
+				URL:        release.URL,
+				MinVersion: release.MinVersion,
+				EOL:        release.EOL,
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, tags); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.write(w, r, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// write writes body with caching headers. If client already has the same
+// body (If-None-Match matches ETag), it responds 304 Not Modified.
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.maxAge().Seconds())))
+	w.Header().Set("Vary", "Accept-Encoding")
+
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, m := range strings.Split(match, ",") {
+			if m = strings.TrimSpace(m); m == etag || m == "*" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)))
+	if r.Method == "HEAD" {
+		return
+	}
+	w.Write(body)
+}
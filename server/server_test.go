@@ -0,0 +1,321 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tcnksm/go-latest"
+)
+
+func TestHandler_implement(t *testing.T) {
+	var _ http.Handler = &Handler{}
+}
+
+func testServer(t *testing.T) *httptest.Server {
+	m, err := LoadManifest("test-fixtures/manifest.json")
+	if err != nil {
+		t.Fatalf("LoadManifest() expects error:%q to be nil", err.Error())
+	}
+	return httptest.NewServer(New(m))
+}
+
+func TestHandler_JSON(t *testing.T) {
+	ts := testServer(t)
+	defer ts.Close()
+
+	tests := []struct {
+		path          string
+		client        *latest.ClientInfo
+		expectCurrent string
+		expectMessage string
+		expectURL     string
+	}{
+		{
+			path:          "/reduce-worker.json",
+			client:        &latest.ClientInfo{Version: "1.2.1"},
+			expectCurrent: "1.2.3",
+			expectMessage: "1.2.x has a CVE, upgrade now",
+		},
+		{
+			path:          "/reduce-worker.json",
+			client:        &latest.ClientInfo{Version: "1.2.2"},
+			expectCurrent: "1.2.3",
+			expectMessage: "New version include security update",
+			expectURL:     "http://example.com/releases/1.2.3",
+		},
+		{
+			path:          "/reduce-worker.json",
+			client:        &latest.ClientInfo{Version: "1.2.1", Channel: "beta"},
+			expectCurrent: "1.3.0-beta1",
+		},
+		{
+			path:          "/reduce-worker.json",
+			expectCurrent: "1.2.3",
+			expectMessage: "New version include security update",
+			expectURL:     "http://example.com/releases/1.2.3",
+		},
+		{
+			path:          "/reduce-worker.json?channel=beta",
+			expectCurrent: "1.3.0-beta1",
+		},
+		{
+			path:          "/great-worker.json",
+			expectCurrent: "0.1.1",
+		},
+	}
+
+	for i, tt := range tests {
+		j := &latest.JSON{URL: ts.URL + tt.path, Client: tt.client}
+		fr, err := j.Fetch()
+		if err != nil {
+			t.Fatalf("#%d Fetch() expects error:%q to be nil", i, err.Error())
+		}
+
+		current := fr.Versions[0].String()
+		if current != tt.expectCurrent {
+			t.Fatalf("#%d Fetch() expects %s to be %s", i, current, tt.expectCurrent)
+		}
+
+		if fr.Meta.Message != tt.expectMessage {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.Message, tt.expectMessage)
+		}
+
+		if fr.Meta.URL != tt.expectURL {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.URL, tt.expectURL)
+		}
+	}
+}
+
+func TestHandler_HTML(t *testing.T) {
+	ts := testServer(t)
+	defer ts.Close()
+
+	tests := []struct {
+		path          string
+		name          string
+		channel       string
+		client        *latest.ClientInfo
+		expectCurrent string
+		expectMessage string
+		expectURL     string
+		expectMin     string
+		expectEOL     string
+	}{
+		{
+			path:          "/",
+			name:          "great-worker",
+			expectCurrent: "0.1.1",
+		},
+		{
+			path:          "/reduce-worker",
+			name:          "reduce-worker",
+			expectCurrent: "1.2.3",
+			expectMessage: "New version include security update",
+			expectURL:     "http://example.com/releases/1.2.3",
+			expectMin:     "1.0.0",
+			expectEOL:     "2016-01-01",
+		},
+		{
+			path:          "/reduce-worker.html",
+			name:          "reduce-worker",
+			channel:       "beta",
+			expectCurrent: "1.3.0-beta1",
+		},
+		{
+			path:          "/reduce-worker",
+			name:          "reduce-worker",
+			client:        &latest.ClientInfo{Version: "1.2.0"},
+			expectCurrent: "1.2.3",
+			expectMessage: "1.2.x has a CVE, upgrade now",
+		},
+	}
+
+	for i, tt := range tests {
+		h := &latest.HTMLMeta{
+			URL:     ts.URL + tt.path,
+			Name:    tt.name,
+			Channel: tt.channel,
+			Client:  tt.client,
+		}
+
+		fr, err := h.Fetch()
+		if err != nil {
+			t.Fatalf("#%d Fetch() expects error:%q to be nil", i, err.Error())
+		}
+
+		current := fr.Versions[0].String()
+		if current != tt.expectCurrent {
+			t.Fatalf("#%d Fetch() expects %s to be %s", i, current, tt.expectCurrent)
+		}
+
+		if fr.Meta.Message != tt.expectMessage {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.Message, tt.expectMessage)
+		}
+
+		if fr.Meta.URL != tt.expectURL {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.URL, tt.expectURL)
+		}
+
+		if fr.Meta.MinVersion != tt.expectMin {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.MinVersion, tt.expectMin)
+		}
+
+		if fr.Meta.EOL != tt.expectEOL {
+			t.Fatalf("#%d Fetch() expects %q to be %q", i, fr.Meta.EOL, tt.expectEOL)
+		}
+	}
+}
+
+func TestHandler_HTMLOrder(t *testing.T) {
+	ts := testServer(t)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/reduce-worker")
+	if err != nil {
+		t.Fatalf("Get() expects error:%q to be nil", err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() expects error:%q to be nil", err.Error())
+	}
+
+	// Clients before channel support use the first meta tag, so it must
+	// be the one of DefaultChannel
+	stable := strings.Index(string(body), `data-channel="stable"`)
+	beta := strings.Index(string(body), `data-channel="beta"`)
+	if stable < 0 || beta < 0 || stable > beta {
+		t.Fatalf("expects stable meta tag to come before beta:\n%s", body)
+	}
+}
+
+func TestHandler_status(t *testing.T) {
+	ts := testServer(t)
+	defer ts.Close()
+
+	tests := []struct {
+		method       string
+		path         string
+		expectStatus int
+	}{
+		{method: "GET", path: "/reduce-worker.json", expectStatus: 200},
+		{method: "HEAD", path: "/reduce-worker", expectStatus: 200},
+		{method: "GET", path: "/unknown.json", expectStatus: 404},
+		{method: "GET", path: "/unknown", expectStatus: 404},
+		{method: "GET", path: "/reduce-worker.json?channel=nightly", expectStatus: 404},
+		{method: "POST", path: "/reduce-worker.json", expectStatus: 405},
+	}
+
+	for i, tt := range tests {
+		req, _ := http.NewRequest(tt.method, ts.URL+tt.path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.expectStatus {
+			t.Fatalf("#%d %s %s expects status %d to be %d", i, tt.method, tt.path, resp.StatusCode, tt.expectStatus)
+		}
+	}
+}
+
+func TestHandler_cache(t *testing.T) {
+	ts := testServer(t)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/reduce-worker.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatalf("expects ETag header to be set")
+	}
+
+	if cc := resp.Header.Get("Cache-Control"); cc != "public, max-age=300" {
+		t.Fatalf("expects Cache-Control %q to be %q", cc, "public, max-age=300")
+	}
+
+	req, _ := http.NewRequest("GET", ts.URL+"/reduce-worker.json", nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("expects status %d to be %d", resp.StatusCode, http.StatusNotModified)
+	}
+}
+
+func TestManifestValidate(t *testing.T) {
+	tests := []struct {
+		manifest  *Manifest
+		expectErr bool
+	}{
+		{
+			manifest: &Manifest{Products: map[string]*Product{
+				"reduce-worker": {Channels: map[string]*Release{"stable": {Version: "1.2.3"}}},
+			}},
+		},
+		{
+			manifest:  &Manifest{},
+			expectErr: true,
+		},
+		{
+			manifest: &Manifest{Products: map[string]*Product{
+				"reduce-worker": {},
+			}},
+			expectErr: true,
+		},
+		{
+			manifest: &Manifest{Products: map[string]*Product{
+				"reduce-worker": {Channels: map[string]*Release{"stable": {Version: "latest"}}},
+			}},
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		err := tt.manifest.Validate()
+		if tt.expectErr == (err == nil) {
+			t.Fatalf("#%d Validate() expects err == nil to eq %t", i, tt.expectErr)
+		}
+	}
+}
+
+func TestHandler_advisories(t *testing.T) {
+	ts := testServer(t)
+	defer ts.Close()
+
+	res, err := latest.Check(&latest.JSON{URL: ts.URL + "/reduce-worker.json"}, "1.2.1")
+	if err != nil {
+		t.Fatalf("Check() expects error:%q to be nil", err.Error())
+	}
+
+	if !res.Vulnerable || len(res.Advisories) != 1 || res.Advisories[0].ID != "CVE-2015-0001" {
+		t.Fatalf("Check() expects 1.2.1 to be vulnerable by CVE-2015-0001: %#v", res.Advisories)
+	}
+}
+
+func TestHandler_retractions(t *testing.T) {
+	ts := testServer(t)
+	defer ts.Close()
+
+	res, err := latest.Check(&latest.JSON{URL: ts.URL + "/reduce-worker.json"}, "1.2.1")
+	if err != nil {
+		t.Fatalf("Check() expects error:%q to be nil", err.Error())
+	}
+
+	if !res.Retracted || res.TargetRelease.Reason != "Corrupts output on retry" {
+		t.Fatalf("Check() expects 1.2.1 to be retracted: %#v", res.TargetRelease)
+	}
+}
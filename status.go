@@ -0,0 +1,50 @@
+package latest
+
+import (
+	"errors"
+)
+
+// Status is how CheckResponse is made.
+type Status int
+
+const (
+	// StatusUnknown is the zero value. Response returned by Checker
+	// never has it.
+	StatusUnknown Status = iota
+
+	// StatusChecked means target is checked with source.
+	StatusChecked
+
+	// StatusSkipped means check is skipped by policy (see Policy).
+	// Only Target and SkipReason are set.
+	StatusSkipped
+
+	// StatusCached means response is loaded from Cache without
+	// accessing source.
+	StatusCached
+
+	// StatusFailedSoft means source can not be reached but the error
+	// is tolerated by Checker.SoftFail. Response is the last cached one
+	// (or only Target is set when nothing is cached) and Err is the error.
+	StatusFailedSoft
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusChecked:
+		return "checked"
+	case StatusSkipped:
+		return "skipped"
+	case StatusCached:
+		return "cached"
+	case StatusFailedSoft:
+		return "failed-soft"
+	}
+	return "unknown"
+}
+
+// softError returns true when err is caused by network (or remote host)
+// and can be tolerated by Checker.SoftFail.
+func softError(err error) bool {
+	return errors.Is(err, ErrNetwork) || errors.Is(err, ErrStatus) || errors.Is(err, ErrRateLimited)
+}
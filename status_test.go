@@ -0,0 +1,176 @@
+package latest
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCheck_status(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-latest")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return now }
+
+	s := &fakeSource{versions: []string{"1.0.0"}}
+	c := &Checker{
+		Source:   s,
+		Cache:    &FileCache{Dir: dir},
+		CacheTTL: time.Hour,
+		SoftFail: true,
+	}
+
+	networkErr := &NetworkError{URL: "http://example.com", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		after         time.Duration
+		err           error
+		expectStatus  Status
+		expectFetched int
+		expectCurrent string
+	}{
+		// Check with source and cache response
+		{after: 0, expectStatus: StatusChecked, expectFetched: 1, expectCurrent: "1.0.0"},
+
+		// Load from cache without accessing source
+		{after: 30 * time.Minute, expectStatus: StatusCached, expectFetched: 1, expectCurrent: "1.0.0"},
+
+		// Cache is expired and source is down: stale cache is used
+		{after: 2 * time.Hour, err: networkErr, expectStatus: StatusFailedSoft, expectFetched: 2, expectCurrent: "1.0.0"},
+
+		// Source is back
+		{after: 3 * time.Hour, expectStatus: StatusChecked, expectFetched: 3, expectCurrent: "1.0.0"},
+	}
+
+	start := now
+	for i, tt := range tests {
+		now = start.Add(tt.after)
+		s.err = tt.err
+
+		res, err := c.Check("0.1.0")
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Status != tt.expectStatus || s.fetched != tt.expectFetched || res.Current != tt.expectCurrent {
+			t.Fatalf("#%d expects status %s (fetched %d, current %q) to be %s (fetched %d, current %q)", i,
+				res.Status, s.fetched, res.Current, tt.expectStatus, tt.expectFetched, tt.expectCurrent)
+		}
+
+		if (res.Err != nil) != (tt.err != nil) {
+			t.Fatalf("#%d expects Err %v to be %v", i, res.Err, tt.err)
+		}
+	}
+}
+
+func TestCheck_softFail(t *testing.T) {
+	networkErr := &NetworkError{URL: "http://example.com", Err: errors.New("connection refused")}
+	parseErr := &ParseError{Input: "http://example.com", Err: errors.New("invalid character")}
+
+	tests := []struct {
+		softFail     bool
+		err          error
+		expectErr    bool
+		expectStatus Status
+	}{
+		{softFail: false, err: networkErr, expectErr: true},
+		{softFail: true, err: networkErr, expectStatus: StatusFailedSoft},
+		{softFail: true, err: &RateLimitError{URL: "http://example.com"}, expectStatus: StatusFailedSoft},
+		{softFail: true, err: parseErr, expectErr: true},
+	}
+
+	for i, tt := range tests {
+		c := &Checker{Source: &fakeSource{err: tt.err}, SoftFail: tt.softFail}
+		res, err := c.Check("0.1.0")
+		if tt.expectErr {
+			if err == nil {
+				t.Fatalf("#%d expects err not to be nil", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d expects err to be nil: %s", i, err)
+		}
+
+		if res.Status != tt.expectStatus || res.Target != "0.1.0" || res.Err != tt.err || res.Current != "" {
+			t.Fatalf("#%d expects degraded response: %#v", i, res)
+		}
+	}
+}
+
+func TestStatusString(t *testing.T) {
+	tests := []struct {
+		status Status
+		expect string
+	}{
+		{StatusUnknown, "unknown"},
+		{Status(0), "unknown"},
+		{StatusChecked, "checked"},
+		{StatusSkipped, "skipped"},
+		{StatusCached, "cached"},
+		{StatusFailedSoft, "failed-soft"},
+	}
+
+	for i, tt := range tests {
+		if got := tt.status.String(); got != tt.expect {
+			t.Fatalf("#%d expects %q to be %q", i, got, tt.expect)
+		}
+	}
+}
+
+// memCache is Cache which holds responses in memory.
+type memCache struct {
+	res    *CheckResponse
+	stored time.Time
+}
+
+func (m *memCache) Load(key string) (*CheckResponse, time.Time, bool) {
+	return m.res, m.stored, m.res != nil
+}
+
+func (m *memCache) Store(key string, res *CheckResponse) error {
+	m.res, m.stored = res, timeNow()
+	return nil
+}
+
+func TestCheck_statusMemCache(t *testing.T) {
+	now := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return now }
+
+	s := &fakeSource{versions: []string{"1.0.0"}}
+	cache := &memCache{}
+	c := &Checker{Source: s, Cache: cache, CacheTTL: time.Hour, SoftFail: true}
+
+	if _, err := c.Check("0.1.0"); err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	// Cached
+	now = now.Add(30 * time.Minute)
+	if _, err := c.Check("0.1.0"); err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+
+	// Failed soft with stale cache
+	now = now.Add(2 * time.Hour)
+	s.err = &NetworkError{URL: "http://example.com", Err: errors.New("connection refused")}
+	res, err := c.Check("0.1.0")
+	if err != nil {
+		t.Fatalf("expects err to be nil: %s", err)
+	}
+	if res.Status != StatusFailedSoft || res == cache.res {
+		t.Fatalf("expects copy of cached response with StatusFailedSoft: %#v", res)
+	}
+
+	if cache.res.Status != StatusChecked || cache.res.Err != nil {
+		t.Fatalf("expects cached response not to be modified: %#v", cache.res)
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/Tcnksm/ghr
+
+go 1.21
+
+require github.com/google/go-github v17.0.0+incompatible
+
+// Published accidentally.
+retract v1.0.0
+
+retract (
+	// Upload fails on large files.
+	[v1.2.0, v1.2.1]
+	v1.1.0 // Broken build.
+)